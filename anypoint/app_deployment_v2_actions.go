@@ -0,0 +1,160 @@
+package anypoint
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	application_manager_v2 "github.com/mulesoft-anypoint/anypoint-client-go/application_manager_v2"
+)
+
+/*
+ * Actions on the application of a Cloudhub 2.0 or Runtime Fabrics deployment, performed without redeploying it.
+ *  - start and stop: applied when the desired_state of the application is the only change.
+ *  - restart: rolling restart of the replicas, triggered by a change of restart_trigger when it is the only change.
+ */
+
+const (
+	APP_DEPLOYMENT_V2_ACTION_START   = "start"
+	APP_DEPLOYMENT_V2_ACTION_STOP    = "stop"
+	APP_DEPLOYMENT_V2_ACTION_RESTART = "restart"
+)
+
+// returns true if the desired state is the only change of the application, target and artifact
+func isAppDeploymentV2DesiredStateChangeOnly(d *schema.ResourceData) bool {
+	if !d.HasChange("application.0.desired_state") || d.HasChanges("target", "artifact_path", "artifact_hash") {
+		return false
+	}
+	old_app, new_app := d.GetChange("application")
+	if len(old_app.([]interface{})) == 0 || len(new_app.([]interface{})) == 0 {
+		return false
+	}
+	for key := range new_app.([]interface{})[0].(map[string]interface{}) {
+		if key != "desired_state" && d.HasChange("application.0."+key) {
+			return false
+		}
+	}
+	return true
+}
+
+// starts or stops the application of the deployment according to its desired state and waits for it
+func applyAppDeploymentV2DesiredState(ctx context.Context, d *schema.ResourceData, pco *ProviderConfOutput, summary string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	orgid := d.Get("org_id").(string)
+	envid := d.Get("env_id").(string)
+	id := d.Id()
+	action := APP_DEPLOYMENT_V2_ACTION_START
+	if d.Get("application.0.desired_state").(string) == APP_DEPLOYMENT_V2_APP_STATE_STOPPED {
+		action = APP_DEPLOYMENT_V2_ACTION_STOP
+	}
+	if err := postAppDeploymentV2Action(ctx, pco, orgid, envid, id, action); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  summary,
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	if res, err := waitAppDeploymentV2Ready(ctx, pco, orgid, envid, id, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		var logs string
+		if res != nil {
+			logs = getAppDeploymentV2LogsTail(ctx, pco, orgid, envid, id, res.GetDesiredVersion())
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  summary,
+			Detail:   err.Error() + logs,
+		})
+	}
+	return diags
+}
+
+// restarts the replicas of the application one after the other and waits for all of them to be replaced and running.
+// a stopped application is left untouched.
+func restartAppDeploymentV2(ctx context.Context, d *schema.ResourceData, pco *ProviderConfOutput, active *application_manager_v2.Deployment, summary string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	orgid := d.Get("org_id").(string)
+	envid := d.Get("env_id").(string)
+	id := d.Id()
+	if d.Get("application.0.desired_state").(string) != APP_DEPLOYMENT_V2_APP_STATE_STARTED {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "The change of restart_trigger is ignored",
+			Detail:   "The application is not started (desired_state " + d.Get("application.0.desired_state").(string) + "), there are no replicas to restart.",
+		})
+		return diags
+	}
+	if err := postAppDeploymentV2Action(ctx, pco, orgid, envid, id, APP_DEPLOYMENT_V2_ACTION_RESTART); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  summary,
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	if res, err := waitAppDeploymentV2Restarted(ctx, pco, orgid, envid, id, active.GetReplicas(), d.Timeout(schema.TimeoutUpdate)); err != nil {
+		var logs string
+		if res != nil {
+			logs = getAppDeploymentV2LogsTail(ctx, pco, orgid, envid, id, res.GetDesiredVersion())
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  summary,
+			Detail:   err.Error() + logs,
+		})
+	}
+	return diags
+}
+
+// waits until the replicas running before the restart have all been replaced by new ones, then until the application is running.
+// the application stays running during a rolling restart, so its status alone doesn't tell when the restart is over.
+func waitAppDeploymentV2Restarted(ctx context.Context, pco *ProviderConfOutput, orgid, envid, id string, previous []application_manager_v2.Replicas, timeout time.Duration) (*application_manager_v2.Deployment, error) {
+	deadline := time.Now().Add(timeout)
+	restarting := make(map[string]bool)
+	for _, replica := range previous {
+		restarting[replica.GetId()] = true
+	}
+	for {
+		res, err := getAppDeploymentV2(ctx, pco, orgid, envid, id)
+		if err != nil {
+			return nil, err
+		}
+		remaining := 0
+		for _, replica := range res.GetReplicas() {
+			if restarting[replica.GetId()] {
+				remaining++
+			}
+		}
+		if remaining == 0 {
+			return waitAppDeploymentV2Ready(ctx, pco, orgid, envid, id, time.Until(deadline))
+		}
+		if time.Now().After(deadline) {
+			return res, fmt.Errorf("timeout while waiting for the replicas of deployment %s to restart, %d of %d replicas have not been restarted", id, remaining, len(restarting))
+		}
+		select {
+		case <-ctx.Done():
+			return res, ctx.Err()
+		case <-time.After(APP_DEPLOYMENT_V2_POLL_INTERVAL):
+		}
+	}
+}
+
+func postAppDeploymentV2Action(ctx context.Context, pco *ProviderConfOutput, orgid, envid, id, action string) error {
+	authctx := getAppDeploymentV2RestAuthCtx(ctx, pco)
+	httpr, err := pco.appmanagerrestclient.Execute(authctx, http.MethodPost, getAppDeploymentV2ActionPath(orgid, envid, id, action), nil, nil)
+	if err != nil {
+		return fmt.Errorf("unable to %s deployment %s. %s", action, id, getHttpErrorDetails(httpr, err))
+	}
+	httpr.Body.Close()
+	return nil
+}
+
+func getAppDeploymentV2ActionPath(orgid, envid, id, action string) string {
+	return fmt.Sprintf("/organizations/%s/environments/%s/deployments/%s/%s",
+		url.PathEscape(orgid), url.PathEscape(envid), url.PathEscape(id), action,
+	)
+}
@@ -0,0 +1,653 @@
+package anypoint
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	application_manager_v2 "github.com/mulesoft-anypoint/anypoint-client-go/application_manager_v2"
+)
+
+/*
+ * Rollout strategies of the Cloudhub 2.0 and Runtime Fabrics deployments.
+ *  - in_place: the deployment is patched, its replicas are updated according to the update_strategy (rolling or recreate).
+ *  - canary: a candidate deployment is created next to the current one with the new configuration.
+ *    Once the candidate is applied and running, the public url and path rewrite are moved to the candidate
+ *    and the current deployment is deleted once the candidate is verified to serve the public url.
+ *  - blue_green: same as canary, except the candidate waits for its explicit promotion (rollout.promote).
+ * The candidate is named after the deployment with the candidate_suffix, the names alternate at each rollout.
+ * With auto_rollback, a failed in_place update rolls the deployment back to its last successful version,
+ * a failed candidate is deleted and the public url is given back to the current deployment.
+ */
+
+const (
+	APP_DEPLOYMENT_V2_ROLLOUT_IN_PLACE   = "in_place"
+	APP_DEPLOYMENT_V2_ROLLOUT_CANARY     = "canary"
+	APP_DEPLOYMENT_V2_ROLLOUT_BLUE_GREEN = "blue_green"
+	APP_DEPLOYMENT_V2_CANDIDATE_SUFFIX   = "-next"
+)
+
+var DeplRolloutDefinition = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"strategy": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Default:  APP_DEPLOYMENT_V2_ROLLOUT_IN_PLACE,
+			Description: `
+			The rollout strategy of the changes: in_place, canary or blue_green.
+			The canary and blue_green strategies require a public url, the traffic is moved to the candidate deployment through it.
+			`,
+			ValidateDiagFunc: validation.ToDiagFunc(
+				validation.StringInSlice([]string{
+					APP_DEPLOYMENT_V2_ROLLOUT_IN_PLACE, APP_DEPLOYMENT_V2_ROLLOUT_CANARY, APP_DEPLOYMENT_V2_ROLLOUT_BLUE_GREEN,
+				}, false),
+			),
+		},
+		"promote": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+			Description: `
+			Only for the blue_green strategy. Whether the candidate deployment is promoted: the public url is moved to the candidate and the current deployment is deleted.
+			The candidate is deployed next to the current deployment and waits for its promotion until promote is set to true, in the same or a later apply.
+			The public url is unavailable while it moves from the current deployment to the candidate, it is given back to the current deployment if the candidate fails to serve it.
+			`,
+		},
+		"auto_rollback": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "Whether a failed rollout is rolled back: the deployment is rolled back to its last successful version for in_place rollouts, the candidate deployment is deleted for canary and blue_green rollouts. Enabled when the rollout block is not set.",
+		},
+		"candidate_suffix": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     APP_DEPLOYMENT_V2_CANDIDATE_SUFFIX,
+			Description: "The suffix added to the name of the deployment to name the candidate deployment of canary and blue_green rollouts.",
+			ValidateDiagFunc: validation.ToDiagFunc(
+				validation.StringMatch(regexp.MustCompile(`^-[a-z0-9-]+$`), "must start with an hyphen followed by lowercase letters, digits or hyphens"),
+			),
+		},
+	},
+}
+
+type appDeploymentV2Rollout struct {
+	strategy     string
+	promote      bool
+	autoRollback bool
+	suffix       string
+}
+
+// builds the deployment request body of the given name, application and target inputs
+type appDeploymentV2BodyFunc func(name string, app_d map[string]interface{}, target_d map[string]interface{}) *application_manager_v2.DeploymentRequestBody
+
+// returns the rollout settings, the schema defaults apply if the rollout block is not set
+func getAppDeploymentV2Rollout(d *schema.ResourceData) *appDeploymentV2Rollout {
+	rollout := &appDeploymentV2Rollout{
+		strategy:     APP_DEPLOYMENT_V2_ROLLOUT_IN_PLACE,
+		autoRollback: true,
+		suffix:       APP_DEPLOYMENT_V2_CANDIDATE_SUFFIX,
+	}
+	if val, ok := d.GetOk("rollout"); ok {
+		list := val.([]interface{})
+		if len(list) > 0 && list[0] != nil {
+			rollout_d := list[0].(map[string]interface{})
+			if val, ok := rollout_d["strategy"].(string); ok {
+				rollout.strategy = val
+			}
+			if val, ok := rollout_d["promote"].(bool); ok {
+				rollout.promote = val
+			}
+			if val, ok := rollout_d["auto_rollback"].(bool); ok {
+				rollout.autoRollback = val
+			}
+			if val, ok := rollout_d["candidate_suffix"].(string); ok {
+				rollout.suffix = val
+			}
+		}
+	}
+	return rollout
+}
+
+// the candidate of a blue_green rollout waits for its explicit promotion, the canary candidate is promoted once running
+func isAppDeploymentV2CandidatePromoted(rollout *appDeploymentV2Rollout) bool {
+	return rollout.strategy != APP_DEPLOYMENT_V2_ROLLOUT_BLUE_GREEN || rollout.promote
+}
+
+// the canary and blue_green strategies move the traffic through the public url, which has to be set.
+// only for the deployments whose public url is part of the configuration (i.e. runtime fabrics),
+// the public url of the other deployments is checked before the candidate is created.
+func validateAppDeploymentV2CandidateRollout(rd *schema.ResourceDiff) error {
+	strategy, _ := rd.Get("rollout.0.strategy").(string)
+	if strategy != APP_DEPLOYMENT_V2_ROLLOUT_CANARY && strategy != APP_DEPLOYMENT_V2_ROLLOUT_BLUE_GREEN {
+		return nil
+	}
+	if !rd.NewValueKnown("target.0.deployment_settings.0.http.0.inbound_public_url") {
+		return nil
+	}
+	if url, _ := rd.Get("target.0.deployment_settings.0.http.0.inbound_public_url").(string); url == "" {
+		return fmt.Errorf("rollout strategy %s requires target.0.deployment_settings.0.http.0.inbound_public_url, the traffic is moved to the candidate deployment through the public url", strategy)
+	}
+	return nil
+}
+
+// applies the changes of the deployment according to its rollout strategy.
+// the resource id is replaced by the candidate's once a canary or blue_green candidate is promoted.
+func updateAppDeploymentV2(ctx context.Context, d *schema.ResourceData, pco *ProviderConfOutput, newBody appDeploymentV2BodyFunc, summary string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	orgid := d.Get("org_id").(string)
+	envid := d.Get("env_id").(string)
+	id := d.Id()
+	rollout := getAppDeploymentV2Rollout(d)
+	app_d, target_d := getAppDeploymentV2Config(d.Get("application"), d.Get("target"))
+	changed := d.HasChanges("application", "target", "artifact_path", "artifact_hash")
+	active, err := getAppDeploymentV2(ctx, pco, orgid, envid, id)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  summary,
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	// the rolling restart is only performed when restart_trigger is the only change
+	if d.HasChange("restart_trigger") && (changed || d.Get("candidate_id").(string) != "") {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "The change of restart_trigger is ignored",
+			Detail:   "The deployment has other changes, they are applied instead of the rolling restart. A rolling restart is only performed when restart_trigger is the only change.",
+		})
+	}
+	// a candidate is pending: a blue_green candidate waiting for its promotion or a candidate kept by a failed rollout
+	if candidateid := d.Get("candidate_id").(string); candidateid != "" {
+		candidate, err := getAppDeploymentV2(ctx, pco, orgid, envid, candidateid)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  summary,
+				Detail:   err.Error(),
+			})
+			return diags
+		}
+		if changed {
+			body := newBody(candidate.GetName(), app_d, withoutAppDeploymentV2PublicUrl(target_d))
+			if res := patchAppDeploymentV2(ctx, d, pco, candidateid, body, summary); res.HasError() {
+				return append(diags, res...)
+			}
+		}
+		if !isAppDeploymentV2CandidatePromoted(rollout) {
+			return diags
+		}
+		return append(diags, promoteAppDeploymentV2Candidate(ctx, d, pco, active, candidate, newBody, rollout, summary)...)
+	}
+	if !changed {
+		if d.HasChange("restart_trigger") {
+			return restartAppDeploymentV2(ctx, d, pco, active, summary)
+		}
+		return diags
+	}
+	// starting or stopping the application doesn't require a new deployment
+	if isAppDeploymentV2DesiredStateChangeOnly(d) {
+		return append(diags, applyAppDeploymentV2DesiredState(ctx, d, pco, summary)...)
+	}
+	if rollout.strategy != APP_DEPLOYMENT_V2_ROLLOUT_IN_PLACE {
+		return append(diags, deployAppDeploymentV2Candidate(ctx, d, pco, active, newBody, rollout, summary)...)
+	}
+	return append(diags, updateAppDeploymentV2InPlace(ctx, d, pco, active, newBody, rollout, summary)...)
+}
+
+// patches the current deployment, rolls it back to its last successful version in case of failure if auto_rollback is enabled
+func updateAppDeploymentV2InPlace(ctx context.Context, d *schema.ResourceData, pco *ProviderConfOutput, active *application_manager_v2.Deployment, newBody appDeploymentV2BodyFunc, rollout *appDeploymentV2Rollout, summary string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	orgid := d.Get("org_id").(string)
+	envid := d.Get("env_id").(string)
+	id := d.Id()
+	app_d, target_d := getAppDeploymentV2Config(d.Get("application"), d.Get("target"))
+	authctx := getAppDeploymentV2AuthCtx(ctx, pco)
+	res, httpr, err := pco.appmanagerclient.DefaultApi.PatchDeployment(authctx, orgid, envid, id).DeploymentRequestBody(*newBody(active.GetName(), app_d, target_d)).Execute()
+	if err != nil {
+		var details string
+		if httpr != nil && httpr.StatusCode >= 400 {
+			defer httpr.Body.Close()
+			b, _ := io.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  summary,
+			Detail:   details + getAppDeploymentV2LogsTail(ctx, pco, orgid, envid, id, active.GetDesiredVersion()),
+		})
+		return diags
+	}
+	defer httpr.Body.Close()
+	//wait for the new version to be applied
+	_, err = waitAppDeploymentV2Ready(ctx, pco, orgid, envid, id, d.Timeout(schema.TimeoutUpdate))
+	if err == nil {
+		return diags
+	}
+	details := err.Error()
+	logs := getAppDeploymentV2LogsTail(ctx, pco, orgid, envid, id, res.GetDesiredVersion())
+	if rollout.autoRollback {
+		// the previous configuration is kept in the state so the next apply retries the rollout
+		d.Partial(true)
+		version := active.GetLastSuccessfulVersion()
+		if failed, err := getAppDeploymentV2(ctx, pco, orgid, envid, id); err == nil && failed.GetLastSuccessfulVersion() != "" {
+			version = failed.GetLastSuccessfulVersion()
+		}
+		if err := rollbackAppDeploymentV2(ctx, pco, orgid, envid, id, version, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			details += fmt.Sprintf("\nunable to roll back to the last successful version %s. %s", version, err)
+		} else {
+			details += fmt.Sprintf("\nrolled back to the last successful version %s.", version)
+		}
+	}
+	diags = append(diags, diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  summary,
+		Detail:   details + logs,
+	})
+	return diags
+}
+
+// creates a candidate deployment with the new configuration next to the current one and promotes it if the rollout allows it
+func deployAppDeploymentV2Candidate(ctx context.Context, d *schema.ResourceData, pco *ProviderConfOutput, active *application_manager_v2.Deployment, newBody appDeploymentV2BodyFunc, rollout *appDeploymentV2Rollout, summary string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	orgid := d.Get("org_id").(string)
+	envid := d.Get("env_id").(string)
+	name := d.Get("name").(string)
+	app_d, target_d := getAppDeploymentV2Config(d.Get("application"), d.Get("target"))
+	// the traffic can't reach the candidate without public url
+	if getAppDeploymentV2Traffic(active, target_d).publicUrl == "" {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  summary,
+			Detail:   fmt.Sprintf("rollout strategy %s requires a public url, the traffic is moved to the candidate deployment through it. Deployment %s has no public url.", rollout.strategy, active.GetName()),
+		})
+		return diags
+	}
+	// the names of the deployments alternate at each rollout
+	candidatename := name + rollout.suffix
+	if active.GetName() != name {
+		candidatename = name
+	}
+	//the candidate doesn't receive the traffic until its promotion
+	body := newBody(candidatename, app_d, withoutAppDeploymentV2PublicUrl(target_d))
+	authctx := getAppDeploymentV2AuthCtx(ctx, pco)
+	candidate, httpr, err := pco.appmanagerclient.DefaultApi.PostDeployment(authctx, orgid, envid).DeploymentRequestBody(*body).Execute()
+	if err != nil {
+		var details string
+		if httpr != nil && httpr.StatusCode >= 400 {
+			defer httpr.Body.Close()
+			b, _ := io.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  summary,
+			Detail:   "unable to create candidate deployment " + candidatename + ". " + details,
+		})
+		return diags
+	}
+	defer httpr.Body.Close()
+	candidateid := candidate.GetId()
+	if _, err := waitAppDeploymentV2Ready(ctx, pco, orgid, envid, candidateid, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		details := err.Error()
+		logs := getAppDeploymentV2LogsTail(ctx, pco, orgid, envid, candidateid, candidate.GetDesiredVersion())
+		if rollout.autoRollback {
+			// the current deployment is left untouched and the previous configuration is kept in the state
+			d.Partial(true)
+			if err := deleteAppDeploymentV2(ctx, pco, orgid, envid, candidateid); err != nil {
+				details += fmt.Sprintf("\nunable to delete candidate deployment %s. %s", candidatename, err)
+			} else {
+				details += fmt.Sprintf("\ncandidate deployment %s has been deleted, deployment %s is left unchanged.", candidatename, active.GetName())
+			}
+		} else {
+			d.Set("candidate_id", candidateid)
+			details += fmt.Sprintf("\ncandidate deployment %s has been kept for troubleshooting.", candidatename)
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  summary,
+			Detail:   details + logs,
+		})
+		return diags
+	}
+	if !isAppDeploymentV2CandidatePromoted(rollout) {
+		d.Set("candidate_id", candidateid)
+		return diags
+	}
+	return promoteAppDeploymentV2Candidate(ctx, d, pco, active, candidate, newBody, rollout, summary)
+}
+
+// moves the public url and path rewrite from the current deployment to the candidate, then deletes the current deployment.
+// if the candidate fails to serve the public url, the url is given back to the current deployment.
+func promoteAppDeploymentV2Candidate(ctx context.Context, d *schema.ResourceData, pco *ProviderConfOutput, active *application_manager_v2.Deployment, candidate *application_manager_v2.Deployment, newBody appDeploymentV2BodyFunc, rollout *appDeploymentV2Rollout, summary string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	orgid := d.Get("org_id").(string)
+	envid := d.Get("env_id").(string)
+	candidateid := candidate.GetId()
+	app_d, target_d := getAppDeploymentV2Config(d.Get("application"), d.Get("target"))
+	activeurl := getAppDeploymentV2LiveTraffic(active).publicUrl
+	traffic := getAppDeploymentV2Traffic(active, target_d)
+	// the public url is released by the current deployment first, it can't be used by two deployments
+	if err := setAppDeploymentV2PublicUrl(ctx, pco, orgid, envid, active.GetId(), ""); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  summary,
+			Detail:   fmt.Sprintf("unable to release the public url of deployment %s. %s", active.GetName(), err),
+		})
+		return diags
+	}
+	body := newBody(candidate.GetName(), app_d, target_d)
+	setAppDeploymentV2BodyTraffic(body, traffic)
+	if err := takeOverAppDeploymentV2PublicUrl(ctx, d, pco, candidate, body, traffic.publicUrl); err != nil {
+		details := err.Error()
+		if err := setAppDeploymentV2PublicUrl(ctx, pco, orgid, envid, candidateid, ""); err != nil {
+			details += fmt.Sprintf("\nunable to release the public url of candidate deployment %s. %s", candidate.GetName(), err)
+		}
+		if err := setAppDeploymentV2PublicUrl(ctx, pco, orgid, envid, active.GetId(), activeurl); err != nil {
+			details += fmt.Sprintf("\nunable to give the public url back to deployment %s, it has to be restored manually. %s", active.GetName(), err)
+		} else {
+			details += fmt.Sprintf("\nthe public url has been given back to deployment %s.", active.GetName())
+		}
+		if rollout.autoRollback {
+			// the current deployment stays the one managed by the resource, with its previous configuration
+			d.Partial(true)
+			if err := deleteAppDeploymentV2(ctx, pco, orgid, envid, candidateid); err != nil {
+				details += fmt.Sprintf("\nunable to delete candidate deployment %s. %s", candidate.GetName(), err)
+			} else {
+				details += fmt.Sprintf("\ncandidate deployment %s has been deleted.", candidate.GetName())
+			}
+		} else {
+			d.Set("candidate_id", candidateid)
+			details += fmt.Sprintf("\ncandidate deployment %s has been kept, its promotion is retried at the next apply.", candidate.GetName())
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  summary,
+			Detail:   details,
+		})
+		return diags
+	}
+	// from now on the candidate is the deployment managed by the resource
+	d.SetId(candidateid)
+	d.Set("candidate_id", "")
+	if err := deleteAppDeploymentV2(ctx, pco, orgid, envid, active.GetId()); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  summary,
+			Detail:   fmt.Sprintf("candidate deployment %s has been promoted but the previous deployment %s couldn't be deleted, it has to be deleted manually. %s", candidate.GetName(), active.GetName(), err),
+		})
+	}
+	return diags
+}
+
+// patches the candidate with the public url, waits for it to be running and verifies it serves the public url
+func takeOverAppDeploymentV2PublicUrl(ctx context.Context, d *schema.ResourceData, pco *ProviderConfOutput, candidate *application_manager_v2.Deployment, body *application_manager_v2.DeploymentRequestBody, url string) error {
+	orgid := d.Get("org_id").(string)
+	envid := d.Get("env_id").(string)
+	candidateid := candidate.GetId()
+	authctx := getAppDeploymentV2AuthCtx(ctx, pco)
+	res, httpr, err := pco.appmanagerclient.DefaultApi.PatchDeployment(authctx, orgid, envid, candidateid).DeploymentRequestBody(*body).Execute()
+	if err != nil {
+		return fmt.Errorf("unable to move the public url to candidate deployment %s. %s", candidate.GetName(), getHttpErrorDetails(httpr, err))
+	}
+	httpr.Body.Close()
+	live, err := waitAppDeploymentV2Ready(ctx, pco, orgid, envid, candidateid, d.Timeout(schema.TimeoutUpdate))
+	if err != nil {
+		return fmt.Errorf("%s%s", err, getAppDeploymentV2LogsTail(ctx, pco, orgid, envid, candidateid, res.GetDesiredVersion()))
+	}
+	if liveurl := getAppDeploymentV2LiveTraffic(live).publicUrl; !equalAppDeploymentV2PublicUrls(liveurl, url) {
+		return fmt.Errorf("candidate deployment %s doesn't serve the public url %s, its public url is %s", candidate.GetName(), url, liveurl)
+	}
+	return nil
+}
+
+// patches the given deployment and waits for the new configuration to be running
+func patchAppDeploymentV2(ctx context.Context, d *schema.ResourceData, pco *ProviderConfOutput, id string, body *application_manager_v2.DeploymentRequestBody, summary string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	orgid := d.Get("org_id").(string)
+	envid := d.Get("env_id").(string)
+	authctx := getAppDeploymentV2AuthCtx(ctx, pco)
+	res, httpr, err := pco.appmanagerclient.DefaultApi.PatchDeployment(authctx, orgid, envid, id).DeploymentRequestBody(*body).Execute()
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  summary,
+			Detail:   "unable to update deployment " + body.GetName() + ". " + getHttpErrorDetails(httpr, err),
+		})
+		return diags
+	}
+	httpr.Body.Close()
+	if _, err := waitAppDeploymentV2Ready(ctx, pco, orgid, envid, id, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  summary,
+			Detail:   err.Error() + getAppDeploymentV2LogsTail(ctx, pco, orgid, envid, id, res.GetDesiredVersion()),
+		})
+	}
+	return diags
+}
+
+// sets the public url of the given deployment, an empty url releases it
+func setAppDeploymentV2PublicUrl(ctx context.Context, pco *ProviderConfOutput, orgid, envid, id, url string) error {
+	inbound := application_manager_v2.NewHttpInbound()
+	inbound.SetPublicUrl(url)
+	http := application_manager_v2.NewHttp()
+	http.SetInbound(*inbound)
+	settings := application_manager_v2.NewDeploymentSettings()
+	settings.SetHttp(*http)
+	target := application_manager_v2.NewTarget()
+	target.SetDeploymentSettings(*settings)
+	body := application_manager_v2.NewDeploymentRequestBody()
+	body.SetTarget(*target)
+	authctx := getAppDeploymentV2AuthCtx(ctx, pco)
+	_, httpr, err := pco.appmanagerclient.DefaultApi.PatchDeployment(authctx, orgid, envid, id).DeploymentRequestBody(*body).Execute()
+	if err != nil {
+		return fmt.Errorf("%s", getHttpErrorDetails(httpr, err))
+	}
+	httpr.Body.Close()
+	return nil
+}
+
+func getAppDeploymentV2(ctx context.Context, pco *ProviderConfOutput, orgid, envid, id string) (*application_manager_v2.Deployment, error) {
+	authctx := getAppDeploymentV2AuthCtx(ctx, pco)
+	res, httpr, err := pco.appmanagerclient.DefaultApi.GetDeploymentById(authctx, orgid, envid, id).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get deployment %s. %s", id, getHttpErrorDetails(httpr, err))
+	}
+	httpr.Body.Close()
+	return res, nil
+}
+
+func deleteAppDeploymentV2(ctx context.Context, pco *ProviderConfOutput, orgid, envid, id string) error {
+	authctx := getAppDeploymentV2AuthCtx(ctx, pco)
+	httpr, err := pco.appmanagerclient.DefaultApi.DeleteDeployment(authctx, orgid, envid, id).Execute()
+	if err != nil {
+		return fmt.Errorf("%s", getHttpErrorDetails(httpr, err))
+	}
+	httpr.Body.Close()
+	return nil
+}
+
+// rolls the deployment back to the given version: the application and target of the version are re-applied,
+// then waits for the deployment to be running.
+func rollbackAppDeploymentV2(ctx context.Context, pco *ProviderConfOutput, orgid, envid, id, version string, timeout time.Duration) error {
+	if version == "" {
+		return fmt.Errorf("deployment %s has no successful version", id)
+	}
+	spec, err := getAppDeploymentV2Spec(ctx, pco, orgid, envid, id, version)
+	if err != nil {
+		return err
+	}
+	authctx := getAppDeploymentV2RestAuthCtx(ctx, pco)
+	body := appDeploymentV2Spec{
+		Application: spec.Application,
+		Target:      spec.Target,
+	}
+	httpr, err := pco.appmanagerrestclient.Execute(authctx, http.MethodPatch, getAppDeploymentV2Path(orgid, envid, id), body, nil)
+	if err != nil {
+		return fmt.Errorf("%s", getHttpErrorDetails(httpr, err))
+	}
+	httpr.Body.Close()
+	_, err = waitAppDeploymentV2Ready(ctx, pco, orgid, envid, id, timeout)
+	return err
+}
+
+// a version of the deployment, the application and target are kept as sent by the platform to be re-applied as is
+type appDeploymentV2Spec struct {
+	Version     string          `json:"version,omitempty"`
+	Application json.RawMessage `json:"application,omitempty"`
+	Target      json.RawMessage `json:"target,omitempty"`
+}
+
+func getAppDeploymentV2Spec(ctx context.Context, pco *ProviderConfOutput, orgid, envid, id, version string) (*appDeploymentV2Spec, error) {
+	authctx := getAppDeploymentV2RestAuthCtx(ctx, pco)
+	var res []appDeploymentV2Spec
+	httpr, err := pco.appmanagerrestclient.Execute(authctx, http.MethodGet, getAppDeploymentV2Path(orgid, envid, id)+"/specs", nil, &res)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the versions of deployment %s. %s", id, getHttpErrorDetails(httpr, err))
+	}
+	httpr.Body.Close()
+	for i := range res {
+		if res[i].Version == version {
+			return &res[i], nil
+		}
+	}
+	return nil, fmt.Errorf("version %s of deployment %s not found", version, id)
+}
+
+func getAppDeploymentV2Path(orgid, envid, id string) string {
+	return fmt.Sprintf("/organizations/%s/environments/%s/deployments/%s",
+		url.PathEscape(orgid), url.PathEscape(envid), url.PathEscape(id),
+	)
+}
+
+// the application and target inputs out of the application and target blocks
+func getAppDeploymentV2Config(app interface{}, target interface{}) (map[string]interface{}, map[string]interface{}) {
+	app_d := make(map[string]interface{})
+	if list := app.([]interface{}); len(list) > 0 && list[0] != nil {
+		app_d = list[0].(map[string]interface{})
+	}
+	target_d := make(map[string]interface{})
+	if list := target.([]interface{}); len(list) > 0 && list[0] != nil {
+		target_d = list[0].(map[string]interface{})
+	}
+	return app_d, target_d
+}
+
+func getAppDeploymentV2PublicUrl(target_d map[string]interface{}) string {
+	if list, ok := target_d["deployment_settings"].([]interface{}); ok && len(list) > 0 && list[0] != nil {
+		settings_d := list[0].(map[string]interface{})
+		if list, ok := settings_d["http"].([]interface{}); ok && len(list) > 0 && list[0] != nil {
+			if url, ok := list[0].(map[string]interface{})["inbound_public_url"].(string); ok {
+				return url
+			}
+		}
+	}
+	return ""
+}
+
+// the public url and path rewrite through which the traffic reaches a deployment
+type appDeploymentV2Traffic struct {
+	publicUrl   string
+	pathRewrite string
+}
+
+// the public url and path rewrite served by the given deployment
+func getAppDeploymentV2LiveTraffic(deployment *application_manager_v2.Deployment) appDeploymentV2Traffic {
+	target := deployment.GetTarget()
+	settings := target.GetDeploymentSettings()
+	http := settings.GetHttp()
+	inbound := http.GetInbound()
+	return appDeploymentV2Traffic{
+		publicUrl:   inbound.GetPublicUrl(),
+		pathRewrite: inbound.GetPathRewrite(),
+	}
+}
+
+// the traffic moved to the candidate of a rollout: the public url of the configuration if set, the ones of the current deployment otherwise
+func getAppDeploymentV2Traffic(active *application_manager_v2.Deployment, target_d map[string]interface{}) appDeploymentV2Traffic {
+	traffic := getAppDeploymentV2LiveTraffic(active)
+	if url := getAppDeploymentV2PublicUrl(target_d); url != "" {
+		traffic.publicUrl = url
+	}
+	return traffic
+}
+
+// sets the public url and path rewrite of the deployment request body, the other inbound settings are kept
+func setAppDeploymentV2BodyTraffic(body *application_manager_v2.DeploymentRequestBody, traffic appDeploymentV2Traffic) {
+	target := body.GetTarget()
+	settings := target.GetDeploymentSettings()
+	http := settings.GetHttp()
+	inbound := http.GetInbound()
+	inbound.SetPublicUrl(traffic.publicUrl)
+	if traffic.pathRewrite != "" {
+		inbound.SetPathRewrite(traffic.pathRewrite)
+	}
+	http.SetInbound(inbound)
+	settings.SetHttp(http)
+	target.SetDeploymentSettings(settings)
+	body.SetTarget(target)
+}
+
+// compares comma separated lists of urls regardless of their order
+func equalAppDeploymentV2PublicUrls(a, b string) bool {
+	split := func(s string) string {
+		urls := make([]string, 0)
+		for _, u := range strings.Split(s, ",") {
+			if u = strings.TrimSpace(u); u != "" {
+				urls = append(urls, u)
+			}
+		}
+		sort.Strings(urls)
+		return strings.Join(urls, ",")
+	}
+	return split(a) == split(b)
+}
+
+// returns a copy of the target input without public url
+func withoutAppDeploymentV2PublicUrl(target_d map[string]interface{}) map[string]interface{} {
+	if getAppDeploymentV2PublicUrl(target_d) == "" {
+		return target_d
+	}
+	settings_d := copyMap(target_d["deployment_settings"].([]interface{})[0].(map[string]interface{}))
+	http_d := copyMap(settings_d["http"].([]interface{})[0].(map[string]interface{}))
+	http_d["inbound_public_url"] = ""
+	settings_d["http"] = []interface{}{http_d}
+	result := copyMap(target_d)
+	result["deployment_settings"] = []interface{}{settings_d}
+	return result
+}
+
+func copyMap(m map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		result[k] = v
+	}
+	return result
+}
+
+// the deployment name stays the one of the configuration when the candidate of a rollout has taken over
+func restoreAppDeploymentV2Name(d *schema.ResourceData, name string) {
+	if name == "" {
+		return
+	}
+	rollout := getAppDeploymentV2Rollout(d)
+	if d.Get("name").(string) == name+rollout.suffix {
+		d.Set("name", name)
+	}
+}
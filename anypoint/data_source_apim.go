@@ -647,6 +647,6 @@ func flattenApimAssetApisResult(apis []apim.ApimInstanceCollectionAssetsInnerApi
  * Returns authentication context (includes authorization header)
  */
 func getApimAuthCtx(ctx context.Context, pco *ProviderConfOutput) context.Context {
-	tmp := context.WithValue(ctx, apim.ContextAccessToken, pco.access_token)
+	tmp := context.WithValue(ctx, apim.ContextAccessToken, pco.tokensource.Token(ctx))
 	return context.WithValue(tmp, apim.ContextServerIndex, pco.server_index)
 }
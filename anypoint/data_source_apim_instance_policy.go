@@ -274,6 +274,6 @@ func flattenApimInstancePolicyPointcutData(collection []apim_policy.PointcutData
  * Returns authentication context (includes authorization header)
  */
 func getApimPolicyAuthCtx(ctx context.Context, pco *ProviderConfOutput) context.Context {
-	tmp := context.WithValue(ctx, apim_policy.ContextAccessToken, pco.access_token)
+	tmp := context.WithValue(ctx, apim_policy.ContextAccessToken, pco.tokensource.Token(ctx))
 	return context.WithValue(tmp, apim_policy.ContextServerIndex, pco.server_index)
 }
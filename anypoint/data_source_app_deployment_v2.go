@@ -5,12 +5,29 @@ import (
 	"fmt"
 	"io"
 	"maps"
+	"net/http"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	application_manager_v2 "github.com/mulesoft-anypoint/anypoint-client-go/application_manager_v2"
 )
 
+const (
+	APP_DEPLOYMENT_V2_STATUS_APPLIED  = "APPLIED"
+	APP_DEPLOYMENT_V2_STATUS_FAILED   = "FAILED"
+	APP_DEPLOYMENT_V2_DEFAULT_TIMEOUT = 10 * time.Minute
+	APP_DEPLOYMENT_V2_POLL_INTERVAL   = 10 * time.Second
+	// application states and statuses
+	APP_DEPLOYMENT_V2_APP_STATE_STARTED            = "STARTED"
+	APP_DEPLOYMENT_V2_APP_STATE_STOPPED            = "STOPPED"
+	APP_DEPLOYMENT_V2_APP_STATUS_RUNNING           = "RUNNING"
+	APP_DEPLOYMENT_V2_APP_STATUS_NOT_RUNNING       = "NOT_RUNNING"
+	APP_DEPLOYMENT_V2_APP_STATUS_FAILED            = "FAILED"
+	APP_DEPLOYMENT_V2_APP_STATUS_DEPLOYMENT_FAILED = "DEPLOYMENT_FAILED"
+)
+
 var ReplicasReadOnlyDefinition = &schema.Resource{
 	Schema: map[string]*schema.Schema{
 		"id": {
@@ -1056,6 +1073,82 @@ func getAppDeploymentV2Attributes() []string {
  * Returns authentication context (includes authorization header)
  */
 func getAppDeploymentV2AuthCtx(ctx context.Context, pco *ProviderConfOutput) context.Context {
-	tmp := context.WithValue(ctx, application_manager_v2.ContextAccessToken, pco.access_token)
+	tmp := context.WithValue(ctx, application_manager_v2.ContextAccessToken, pco.tokensource.Token(ctx))
 	return context.WithValue(tmp, application_manager_v2.ContextServerIndex, pco.server_index)
 }
+
+// waits until the given deployment is applied, fails if the deployment failed.
+// the error includes the reasons reported by the failed replicas.
+func waitAppDeploymentV2Applied(ctx context.Context, pco *ProviderConfOutput, orgid, envid, id string, timeout time.Duration) (*application_manager_v2.Deployment, error) {
+	deadline := time.Now().Add(timeout)
+	authctx := getAppDeploymentV2AuthCtx(ctx, pco)
+	for {
+		res, httpr, err := pco.appmanagerclient.DefaultApi.GetDeploymentById(authctx, orgid, envid, id).Execute()
+		if err != nil {
+			return nil, fmt.Errorf("unable to get deployment %s. %s", id, getHttpErrorDetails(httpr, err))
+		}
+		httpr.Body.Close()
+		status := res.GetStatus()
+		if status == APP_DEPLOYMENT_V2_STATUS_APPLIED {
+			return res, nil
+		}
+		if status == APP_DEPLOYMENT_V2_STATUS_FAILED {
+			reasons := make([]string, 0)
+			for _, replica := range res.GetReplicas() {
+				if reason := replica.GetReason(); reason != "" {
+					reasons = append(reasons, replica.GetId()+": "+reason)
+				}
+			}
+			return res, fmt.Errorf("deployment %s failed. %s", id, strings.Join(reasons, "\n"))
+		}
+		if time.Now().After(deadline) {
+			return res, fmt.Errorf("timeout while waiting for deployment %s to be applied, current status is %s", id, status)
+		}
+		select {
+		case <-ctx.Done():
+			return res, ctx.Err()
+		case <-time.After(APP_DEPLOYMENT_V2_POLL_INTERVAL):
+		}
+	}
+}
+
+// waits until the given deployment is applied and its application has reached its desired state:
+// running when STARTED, not running when STOPPED.
+// returns an error if the deployment or the application fails, or if the timeout is reached.
+func waitAppDeploymentV2Ready(ctx context.Context, pco *ProviderConfOutput, orgid, envid, id string, timeout time.Duration) (*application_manager_v2.Deployment, error) {
+	deadline := time.Now().Add(timeout)
+	res, err := waitAppDeploymentV2Applied(ctx, pco, orgid, envid, id, timeout)
+	if err != nil {
+		return res, err
+	}
+	authctx := getAppDeploymentV2AuthCtx(ctx, pco)
+	for {
+		application := res.GetApplication()
+		state := application.GetDesiredState()
+		status := application.GetStatus()
+		switch {
+		case state == APP_DEPLOYMENT_V2_APP_STATE_STARTED && status == APP_DEPLOYMENT_V2_APP_STATUS_RUNNING:
+			return res, nil
+		case state == APP_DEPLOYMENT_V2_APP_STATE_STOPPED && status == APP_DEPLOYMENT_V2_APP_STATUS_NOT_RUNNING:
+			return res, nil
+		case state != APP_DEPLOYMENT_V2_APP_STATE_STARTED && state != APP_DEPLOYMENT_V2_APP_STATE_STOPPED:
+			return res, nil
+		case status == APP_DEPLOYMENT_V2_APP_STATUS_FAILED || status == APP_DEPLOYMENT_V2_APP_STATUS_DEPLOYMENT_FAILED:
+			return res, fmt.Errorf("application of deployment %s failed, status is %s", id, status)
+		}
+		if time.Now().After(deadline) {
+			return res, fmt.Errorf("timeout while waiting for the application of deployment %s to be %s, current status is %s", id, strings.ToLower(state), status)
+		}
+		select {
+		case <-ctx.Done():
+			return res, ctx.Err()
+		case <-time.After(APP_DEPLOYMENT_V2_POLL_INTERVAL):
+		}
+		var httpr *http.Response
+		res, httpr, err = pco.appmanagerclient.DefaultApi.GetDeploymentById(authctx, orgid, envid, id).Execute()
+		if err != nil {
+			return nil, fmt.Errorf("unable to get deployment %s. %s", id, getHttpErrorDetails(httpr, err))
+		}
+		httpr.Body.Close()
+	}
+}
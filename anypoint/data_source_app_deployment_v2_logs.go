@@ -0,0 +1,342 @@
+package anypoint
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	// the number of log lines appended to the deployment failures
+	APP_DEPLOYMENT_V2_LOGS_TAIL = 20
+	APP_DEPLOYMENT_V2_LOGS_MAX  = 1000
+)
+
+type appDeploymentV2Log struct {
+	Timestamp int64  `json:"timestamp"`
+	Message   string `json:"message"`
+	LogLevel  string `json:"logLevel,omitempty"`
+	ReplicaId string `json:"replicaId,omitempty"`
+}
+
+// the filters of a logs request
+type appDeploymentV2LogsQuery struct {
+	limit     int
+	startTime *time.Time
+	endTime   *time.Time
+	priority  string
+	search    string
+}
+
+func dataSourceAppDeploymentV2Logs() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAppDeploymentV2LogsRead,
+		Description: `
+		Reads the logs and the replicas status of a specific ` + "`" + `Deployment` + "`" + `.
+		By default the latest log lines are returned, they can be filtered by time range, priority and text.
+		This only works for Cloudhub V2 and Runtime Fabrics Apps.
+		`,
+		Schema: map[string]*schema.Schema{
+			"org_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The organization where the mule app is deployed.",
+			},
+			"env_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The environment where mule app is deployed.",
+			},
+			"deployment_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The unique id of the mule app deployment in the platform.",
+			},
+			"spec_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The deployment version (spec) to read the logs of. Defaults to the deployment desired version.",
+			},
+			"replica_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Restricts the logs to the given replica. The logs of all replicas are returned if not set.",
+			},
+			"tail": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          100,
+				Description:      "The maximum number of log lines to return, the latest lines are returned first. Maximum 1000.",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(1, APP_DEPLOYMENT_V2_LOGS_MAX)),
+			},
+			"start_time": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "Only returns the log lines logged after the given time (RFC3339 format), i.e. 2024-01-02T15:04:05Z.",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsRFC3339Time),
+			},
+			"end_time": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "Only returns the log lines logged before the given time (RFC3339 format), i.e. 2024-01-02T15:04:05Z.",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsRFC3339Time),
+			},
+			"priority": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only returns the log lines of the given priority: DEBUG, INFO, WARN, ERROR or FATAL.",
+				ValidateDiagFunc: validation.ToDiagFunc(
+					validation.StringInSlice([]string{"DEBUG", "INFO", "WARN", "ERROR", "FATAL"}, false),
+				),
+			},
+			"search": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only returns the log lines containing the given text.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the deployment.",
+			},
+			"replicas": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Data of the mule app replicas",
+				Elem:        ReplicasReadOnlyDefinition,
+			},
+			"logs": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The log lines, in chronological order.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"timestamp": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time of the log line (RFC3339 format).",
+						},
+						"priority": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The priority of the log line.",
+						},
+						"message": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The message of the log line.",
+						},
+						"replica_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The replica that produced the log line.",
+						},
+					},
+				},
+			},
+			"total": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of log lines returned.",
+			},
+		},
+	}
+}
+
+func dataSourceAppDeploymentV2LogsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid := d.Get("org_id").(string)
+	envid := d.Get("env_id").(string)
+	id := d.Get("deployment_id").(string)
+	specid := d.Get("spec_id").(string)
+	replicaid := d.Get("replica_id").(string)
+	authctx := getAppDeploymentV2AuthCtx(ctx, &pco)
+	//read the deployment status
+	res, httpr, err := pco.appmanagerclient.DefaultApi.GetDeploymentById(authctx, orgid, envid, id).Execute()
+	if err != nil {
+		var details string
+		if httpr != nil && httpr.StatusCode >= 400 {
+			defer httpr.Body.Close()
+			b, _ := io.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to get deployment for org " + orgid + " and env " + envid + " with id " + id,
+			Detail:   details,
+		})
+		return diags
+	}
+	defer httpr.Body.Close()
+	if specid == "" {
+		specid = res.GetDesiredVersion()
+	}
+	//read the logs
+	query, err := newAppDeploymentV2LogsQuery(d)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to parse logs filters of deployment " + id,
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	logs, err := getAppDeploymentV2Logs(ctx, &pco, orgid, envid, id, specid, replicaid, query)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to get logs of deployment " + id,
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	//process data
+	if err := d.Set("replicas", flattenAppDeploymentV2Replicas(res.GetReplicas())); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to set replicas of deployment " + id,
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	if err := d.Set("logs", flattenAppDeploymentV2Logs(logs)); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to set logs of deployment " + id,
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	d.Set("status", res.GetStatus())
+	d.Set("spec_id", specid)
+	d.Set("total", len(logs))
+	d.SetId(ComposeResourceId([]string{orgid, envid, id, specid}))
+	return diags
+}
+
+func newAppDeploymentV2LogsQuery(d *schema.ResourceData) (*appDeploymentV2LogsQuery, error) {
+	query := &appDeploymentV2LogsQuery{
+		limit:    d.Get("tail").(int),
+		priority: d.Get("priority").(string),
+		search:   d.Get("search").(string),
+	}
+	if val, ok := d.GetOk("start_time"); ok {
+		t, err := time.Parse(time.RFC3339, val.(string))
+		if err != nil {
+			return nil, err
+		}
+		query.startTime = &t
+	}
+	if val, ok := d.GetOk("end_time"); ok {
+		t, err := time.Parse(time.RFC3339, val.(string))
+		if err != nil {
+			return nil, err
+		}
+		query.endTime = &t
+	}
+	return query, nil
+}
+
+// returns the log lines of the given deployment version, in chronological order.
+// the logs of a single replica are returned if replicaid is not empty.
+func getAppDeploymentV2Logs(ctx context.Context, pco *ProviderConfOutput, orgid, envid, id, specid, replicaid string, query *appDeploymentV2LogsQuery) ([]appDeploymentV2Log, error) {
+	authctx := getAppDeploymentV2RestAuthCtx(ctx, pco)
+	path := getAppDeploymentV2LogsPath(orgid, envid, id, specid, replicaid)
+	// the latest lines are requested first, then put back in chronological order
+	params := url.Values{}
+	params.Set("descending", "true")
+	params.Set("limit", strconv.Itoa(query.limit))
+	if query.startTime != nil {
+		params.Set("startTime", strconv.FormatInt(query.startTime.UnixMilli(), 10))
+	}
+	if query.endTime != nil {
+		params.Set("endTime", strconv.FormatInt(query.endTime.UnixMilli(), 10))
+	}
+	if query.priority != "" {
+		params.Set("logLevel", query.priority)
+	}
+	if query.search != "" {
+		params.Set("search", query.search)
+	}
+	var res []appDeploymentV2Log
+	httpr, err := pco.appmanagerrestclient.Execute(authctx, http.MethodGet, path+"?"+params.Encode(), nil, &res)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get logs of deployment %s version %s. %s", id, specid, getHttpErrorDetails(httpr, err))
+	}
+	httpr.Body.Close()
+	for i, j := 0, len(res)-1; i < j; i, j = i+1, j-1 {
+		res[i], res[j] = res[j], res[i]
+	}
+	return res, nil
+}
+
+// returns the latest log lines of the given deployment as text, to be added to the details of a failure.
+// returns an empty string if the logs are not available.
+func getAppDeploymentV2LogsTail(ctx context.Context, pco *ProviderConfOutput, orgid, envid, id, specid string) string {
+	if id == "" || specid == "" {
+		return ""
+	}
+	logs, err := getAppDeploymentV2Logs(ctx, pco, orgid, envid, id, specid, "", &appDeploymentV2LogsQuery{limit: APP_DEPLOYMENT_V2_LOGS_TAIL})
+	if err != nil {
+		log.Printf("[WARN] %s", err)
+		return ""
+	}
+	if len(logs) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "\n\nlast %d log lines of deployment %s:\n", len(logs), id)
+	for _, l := range logs {
+		fmt.Fprintf(&sb, "%s %-5s %s\n", formatAppDeploymentV2LogTime(l.Timestamp), l.LogLevel, l.Message)
+	}
+	return sb.String()
+}
+
+func flattenAppDeploymentV2Logs(logs []appDeploymentV2Log) []interface{} {
+	res := make([]interface{}, len(logs))
+	for i, l := range logs {
+		item := make(map[string]interface{})
+		item["timestamp"] = formatAppDeploymentV2LogTime(l.Timestamp)
+		item["priority"] = l.LogLevel
+		item["message"] = l.Message
+		item["replica_id"] = l.ReplicaId
+		res[i] = item
+	}
+	return res
+}
+
+func formatAppDeploymentV2LogTime(timestamp int64) string {
+	return time.UnixMilli(timestamp).UTC().Format(time.RFC3339)
+}
+
+func getAppDeploymentV2LogsPath(orgid, envid, id, specid, replicaid string) string {
+	path := fmt.Sprintf("/organizations/%s/environments/%s/deployments/%s/specs/%s",
+		url.PathEscape(orgid), url.PathEscape(envid), url.PathEscape(id), url.PathEscape(specid),
+	)
+	if replicaid != "" {
+		path += "/replicas/" + url.PathEscape(replicaid)
+	}
+	return path + "/logs"
+}
+
+/*
+ * Returns authentication context (includes authorization header)
+ */
+func getAppDeploymentV2RestAuthCtx(ctx context.Context, pco *ProviderConfOutput) context.Context {
+	tmp := context.WithValue(ctx, RestContextAccessToken, pco.tokensource.Token(ctx))
+	return context.WithValue(tmp, RestContextServerIndex, pco.server_index)
+}
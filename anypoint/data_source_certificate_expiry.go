@@ -0,0 +1,376 @@
+package anypoint
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/mulesoft-anypoint/anypoint-client-go/dlb"
+)
+
+// the certificate of a dlb ssl endpoint
+type dlbCertificate struct {
+	PublicKeyLabel  string `json:"publicKeyLabel"`
+	PublicKeyDigest string `json:"publicKeyDigest"`
+	PublicKeyCN     string `json:"publicKeyCN"`
+	PublicKey       string `json:"publicKey"`
+}
+
+// the expiration date layouts returned by the secrets manager
+var CERTIFICATE_EXPIRATION_DATE_LAYOUTS = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+func dataSourceCertificateExpiry() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceCertificateExpiryRead,
+		Description: `
+		Reports the certificates expiring within a given number of days.
+		Scans the keystores, truststores and certificates of all secret groups in the given environment and/or the ssl endpoints of all dlbs in the given vpc.
+		`,
+		Schema: map[string]*schema.Schema{
+			"org_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The organization id where the secret groups and dlbs are defined.",
+			},
+			"env_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				AtLeastOneOf: []string{"env_id", "vpc_id"},
+				Description:  "The environment id whose secret groups are scanned.",
+			},
+			"vpc_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				AtLeastOneOf: []string{"env_id", "vpc_id"},
+				Description:  "The vpc id whose dlbs ssl endpoints are scanned.",
+			},
+			"days": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          30,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+				Description:      "Reports the certificates expiring within the given number of days. Already expired certificates are always reported.",
+			},
+			"certificates": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The certificates expiring within the given number of days, the ones expiring first come first.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"source": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Where the certificate is defined. Either secretgroup_keystore, secretgroup_truststore, secretgroup_certificate or dlb_ssl_endpoint.",
+						},
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The id of the secret, or the public key digest in the case of a dlb ssl endpoint.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the secret, or the public key label in the case of a dlb ssl endpoint.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the secret (i.e. PEM, JKS...). Empty in the case of a dlb ssl endpoint.",
+						},
+						"sg_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The secret group id, in the case of a secret.",
+						},
+						"sg_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The secret group name, in the case of a secret.",
+						},
+						"dlb_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The dlb id, in the case of a dlb ssl endpoint.",
+						},
+						"dlb_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The dlb name, in the case of a dlb ssl endpoint.",
+						},
+						"common_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The common name of the certificate, in the case of a dlb ssl endpoint.",
+						},
+						"expiration_date": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The expiration date of the certificate in RFC3339 format.",
+						},
+						"days_remaining": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of days remaining before the certificate expires, negative if already expired.",
+						},
+						"expired": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the certificate is already expired.",
+						},
+					},
+				},
+			},
+			"total": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The total number of expiring certificates.",
+			},
+		},
+	}
+}
+
+func dataSourceCertificateExpiryRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid := d.Get("org_id").(string)
+	envid := d.Get("env_id").(string)
+	vpcid := d.Get("vpc_id").(string)
+	days := d.Get("days").(int)
+	limit := time.Now().AddDate(0, 0, days)
+	certificates := make([]interface{}, 0)
+	//scan secret groups
+	if envid != "" {
+		list, err := scanSgCertificatesExpiry(ctx, &pco, orgid, envid, limit)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to scan secret groups certificates for org " + orgid + " and env " + envid,
+				Detail:   err.Error(),
+			})
+			return diags
+		}
+		certificates = append(certificates, list...)
+	}
+	//scan dlbs
+	if vpcid != "" {
+		list, err := scanDlbCertificatesExpiry(ctx, &pco, orgid, vpcid, limit)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to scan dlbs certificates for org " + orgid + " and vpc " + vpcid,
+				Detail:   err.Error(),
+			})
+			return diags
+		}
+		certificates = append(certificates, list...)
+	}
+	//sort by expiration
+	sort.SliceStable(certificates, func(i, j int) bool {
+		i_elem := certificates[i].(map[string]interface{})
+		j_elem := certificates[j].(map[string]interface{})
+		return i_elem["days_remaining"].(int) < j_elem["days_remaining"].(int)
+	})
+	//save in data source schema
+	if err := d.Set("certificates", certificates); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to set expiring certificates for org " + orgid,
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	if err := d.Set("total", len(certificates)); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to set total number of expiring certificates for org " + orgid,
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+
+	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+
+	return diags
+}
+
+// lists the keystores, truststores and certificates of all secret groups in the given environment expiring before the given limit
+func scanSgCertificatesExpiry(ctx context.Context, pco *ProviderConfOutput, orgid, envid string, limit time.Time) ([]interface{}, error) {
+	result := make([]interface{}, 0)
+	sgs, httpr, err := pco.secretgroupclient.DefaultApi.GetEnvSecretGroups(getSecretGroupAuthCtx(ctx, pco), orgid, envid).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("unable to list secret groups. %s", getHttpErrorDetails(httpr, err))
+	}
+	httpr.Body.Close()
+	for _, sg := range sgs {
+		sgmeta := sg.GetMeta()
+		sgid := sgmeta.GetId()
+		sgname := sg.GetName()
+		//keystores
+		keystores, httpr, err := pco.sgkeystoreclient.DefaultApi.GetSecretGroupKeystores(getSgKeystoreAuthCtx(ctx, pco), orgid, envid, sgid).Execute()
+		if err != nil {
+			return nil, fmt.Errorf("unable to list keystores of secret group %s. %s", sgid, getHttpErrorDetails(httpr, err))
+		}
+		httpr.Body.Close()
+		for _, ks := range keystores {
+			meta := ks.GetMeta()
+			item := newSgCertificateExpiryItem("secretgroup_keystore", sgid, sgname, meta.GetId(), ks.GetName(), ks.GetType())
+			if err := addCertificateExpiryItem(&result, item, ks.GetExpirationDate(), limit); err != nil {
+				return nil, fmt.Errorf("unable to parse the expiration date of keystore %s. %s", meta.GetId(), err)
+			}
+		}
+		//truststores
+		truststores, httpr, err := pco.sgtruststoreclient.DefaultApi.GetSecretGroupTruststores(getSgTruststoreAuthCtx(ctx, pco), orgid, envid, sgid).Execute()
+		if err != nil {
+			return nil, fmt.Errorf("unable to list truststores of secret group %s. %s", sgid, getHttpErrorDetails(httpr, err))
+		}
+		httpr.Body.Close()
+		for _, ts := range truststores {
+			meta := ts.GetMeta()
+			item := newSgCertificateExpiryItem("secretgroup_truststore", sgid, sgname, meta.GetId(), ts.GetName(), ts.GetType())
+			if err := addCertificateExpiryItem(&result, item, ts.GetExpirationDate(), limit); err != nil {
+				return nil, fmt.Errorf("unable to parse the expiration date of truststore %s. %s", meta.GetId(), err)
+			}
+		}
+		//certificates
+		certs, httpr, err := pco.sgcertificateclient.DefaultApi.GetSecretGroupCertificates(getSgCertificateAuthCtx(ctx, pco), orgid, envid, sgid).Execute()
+		if err != nil {
+			return nil, fmt.Errorf("unable to list certificates of secret group %s. %s", sgid, getHttpErrorDetails(httpr, err))
+		}
+		httpr.Body.Close()
+		for _, cert := range certs {
+			meta := cert.GetMeta()
+			item := newSgCertificateExpiryItem("secretgroup_certificate", sgid, sgname, meta.GetId(), cert.GetName(), cert.GetType())
+			if err := addCertificateExpiryItem(&result, item, cert.GetExpirationDate(), limit); err != nil {
+				return nil, fmt.Errorf("unable to parse the expiration date of certificate %s. %s", meta.GetId(), err)
+			}
+		}
+	}
+	return result, nil
+}
+
+// lists the certificates of all dlbs ssl endpoints in the given vpc expiring before the given limit
+func scanDlbCertificatesExpiry(ctx context.Context, pco *ProviderConfOutput, orgid, vpcid string, limit time.Time) ([]interface{}, error) {
+	result := make([]interface{}, 0)
+	res, httpr, err := pco.dlbclient.DefaultApi.OrganizationsOrgIdVpcsVpcIdLoadbalancersGet(getDLBAuthCtx(ctx, pco), orgid, vpcid).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("unable to list dlbs. %s", getHttpErrorDetails(httpr, err))
+	}
+	httpr.Body.Close()
+	for _, lb := range res.GetData() {
+		for _, endpoint := range lb.GetSslEndpoints() {
+			item, err := readDlbCertificateExpiry(ctx, pco, orgid, vpcid, &lb, &endpoint)
+			if err != nil {
+				return nil, err
+			}
+			if item == nil {
+				continue
+			}
+			if err := addCertificateExpiryItem(&result, item, item["expiration_date"].(string), limit); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return result, nil
+}
+
+// reads and decodes the certificate of the given dlb ssl endpoint, returns nil if the endpoint has no certificate
+func readDlbCertificateExpiry(ctx context.Context, pco *ProviderConfOutput, orgid, vpcid string, lb *dlb.Dlb, endpoint *dlb.DlbCoreSslEndpoints) (map[string]interface{}, error) {
+	digest := endpoint.GetPublicKeyDigest()
+	if digest == "" {
+		return nil, nil
+	}
+	var res dlbCertificate
+	httpr, err := pco.dlbcertificateclient.Execute(getDlbCertificateAuthCtx(ctx, pco), http.MethodGet, getDlbCertificatePath(orgid, vpcid, lb.GetId(), digest), nil, &res)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get certificate %s of dlb %s. %s", digest, lb.GetId(), getHttpErrorDetails(httpr, err))
+	}
+	httpr.Body.Close()
+	cert, err := DecodeX509Certificate(res.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode certificate %s of dlb %s. %s", digest, lb.GetId(), err.Error())
+	}
+	item := map[string]interface{}{
+		"source":          "dlb_ssl_endpoint",
+		"id":              digest,
+		"name":            endpoint.GetPublicKeyLabel(),
+		"type":            "",
+		"sg_id":           "",
+		"sg_name":         "",
+		"dlb_id":          lb.GetId(),
+		"dlb_name":        lb.GetName(),
+		"common_name":     cert.Subject.CommonName,
+		"expiration_date": cert.NotAfter.Format(time.RFC3339),
+	}
+	return item, nil
+}
+
+func newSgCertificateExpiryItem(source, sgid, sgname, id, name, t string) map[string]interface{} {
+	return map[string]interface{}{
+		"source":      source,
+		"id":          id,
+		"name":        name,
+		"type":        t,
+		"sg_id":       sgid,
+		"sg_name":     sgname,
+		"dlb_id":      "",
+		"dlb_name":    "",
+		"common_name": "",
+	}
+}
+
+// adds the given item to the result if the expiration date is before the limit. Items without expiration date are ignored
+func addCertificateExpiryItem(result *[]interface{}, item map[string]interface{}, expiration string, limit time.Time) error {
+	if expiration == "" {
+		return nil
+	}
+	date, err := parseCertificateExpirationDate(expiration)
+	if err != nil {
+		return err
+	}
+	if !date.Before(limit) {
+		return nil
+	}
+	remaining := time.Until(date).Hours() / 24
+	item["expiration_date"] = date.Format(time.RFC3339)
+	item["days_remaining"] = int(math.Floor(remaining))
+	item["expired"] = remaining < 0
+	*result = append(*result, item)
+	return nil
+}
+
+func parseCertificateExpirationDate(source string) (time.Time, error) {
+	for _, layout := range CERTIFICATE_EXPIRATION_DATE_LAYOUTS {
+		if date, err := time.Parse(layout, source); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unsupported date format %s", source)
+}
+
+func getDlbCertificatePath(orgid, vpcid, dlbid, digest string) string {
+	return fmt.Sprintf(
+		"/organizations/%s/vpcs/%s/loadbalancers/%s/certificates/%s",
+		url.PathEscape(orgid), url.PathEscape(vpcid), url.PathEscape(dlbid), url.PathEscape(digest),
+	)
+}
+
+/*
+ * Returns authentication context (includes authorization header)
+ */
+func getDlbCertificateAuthCtx(ctx context.Context, pco *ProviderConfOutput) context.Context {
+	tmp := context.WithValue(ctx, RestContextAccessToken, pco.tokensource.Token(ctx))
+	return context.WithValue(tmp, RestContextServerIndex, pco.server_index)
+}
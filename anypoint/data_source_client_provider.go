@@ -0,0 +1,155 @@
+package anypoint
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceClientProvider() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceClientProviderRead,
+		Description: `
+		Reads a specific ` + "`" + `client provider` + "`" + ` in your organization.
+		`,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The unique id of the client provider.",
+			},
+			"org_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The master organization id where the client provider is defined.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the client provider.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The description of the client provider.",
+			},
+			"type": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "The type of the client provider, contains description and the name of the type of the provider.",
+			},
+			"issuer": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The openid-connect provider's token issuer url.",
+			},
+			"registration_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The openid-connect provider's dynamic client registration url.",
+			},
+			"introspection_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The openid-connect provider's token introspection url.",
+			},
+			"authorize_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The openid-connect provider's authorization url.",
+			},
+			"token_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The openid-connect provider's token url.",
+			},
+			"client_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The id of the client used by anypoint to introspect tokens.",
+			},
+			"connection_timeout": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The timeout in milliseconds for opening a connection to the openid-connect provider.",
+			},
+			"read_timeout": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The timeout in milliseconds for reading the openid-connect provider's responses.",
+			},
+			"allow_untrusted_certificates": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether untrusted certificates are accepted when connecting to the openid-connect provider.",
+			},
+			"allow_client_import": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether clients created outside of anypoint can be imported.",
+			},
+			"allow_external_client_modification": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether clients created outside of anypoint can be modified.",
+			},
+			"allow_local_client_deletion": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether clients deleted in anypoint are only deleted locally (not in the openid-connect provider).",
+			},
+			"environment_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The list of environment ids the client provider is assigned to.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceClientProviderRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	providerid := d.Get("id").(string)
+	orgid := d.Get("org_id").(string)
+	authctx := getAccountsAuthCtx(ctx, &pco)
+	//perform request
+	var res clientProvider
+	httpr, err := pco.accountsclient.Execute(authctx, http.MethodGet, getClientProviderPath(orgid, providerid), nil, &res)
+	if err != nil {
+		var details string
+		if httpr != nil && httpr.StatusCode >= 400 {
+			defer httpr.Body.Close()
+			b, _ := io.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		diags := append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to get client provider " + providerid + " in org " + orgid,
+			Detail:   details,
+		})
+		return diags
+	}
+	defer httpr.Body.Close()
+	//process data
+	data := flattenClientProviderData(&res)
+	//save in data source schema
+	if err := setClientProviderAttributesToResourceData(d, data); err != nil {
+		diags := append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to set client provider " + providerid + " in org " + orgid,
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	d.SetId(providerid)
+	return diags
+}
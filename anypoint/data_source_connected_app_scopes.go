@@ -0,0 +1,150 @@
+package anypoint
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	CONNECTED_APP_SCOPE_CTX_ORG = "org"
+	CONNECTED_APP_SCOPE_CTX_ENV = "envId"
+	CONNECTED_APP_SCOPES_PATH   = "/connectedApplications/scopes"
+)
+
+type connectedAppScopeInfo struct {
+	Scope         string   `json:"scope"`
+	Description   string   `json:"description,omitempty"`
+	ContextParams []string `json:"context_params,omitempty"`
+}
+
+type connectedAppScopesResponse struct {
+	Data  []connectedAppScopeInfo `json:"data"`
+	Total int                     `json:"total,omitempty"`
+}
+
+func dataSourceConnectedAppScopes() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceConnectedAppScopesRead,
+		Description: `
+		Reads the catalog of scopes that can be granted to ` + "`" + `connected apps` + "`" + `.
+		For each scope, indicates whether the business group (org_id) and/or the environment (env_id) context params are required.
+		`,
+		Schema: map[string]*schema.Schema{
+			"scopes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The list of available scopes",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"scope": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The scope",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The description of the scope",
+						},
+						"requires_org": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the business group (org_id) is required when granting this scope",
+						},
+						"requires_env": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the environment (env_id) is required when granting this scope",
+						},
+					},
+				},
+			},
+			"total": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The total number of available scopes",
+			},
+		},
+	}
+}
+
+func dataSourceConnectedAppScopesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	authctx := getAccountsAuthCtx(ctx, &pco)
+	//perform request
+	var res connectedAppScopesResponse
+	httpr, err := pco.accountsclient.Execute(authctx, http.MethodGet, CONNECTED_APP_SCOPES_PATH, nil, &res)
+	if err != nil {
+		var details string
+		if httpr != nil && httpr.StatusCode >= 400 {
+			defer httpr.Body.Close()
+			b, _ := io.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		diags := append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to get connected app scopes",
+			Detail:   details,
+		})
+		return diags
+	}
+	defer httpr.Body.Close()
+	//process data
+	scopes := flattenConnectedAppScopesCatalog(res.Data)
+	//save in data source schema
+	if err := d.Set("scopes", scopes); err != nil {
+		diags := append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to set connected app scopes",
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	d.Set("total", len(scopes))
+	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+
+	return diags
+}
+
+func flattenConnectedAppScopesCatalog(scopes []connectedAppScopeInfo) []interface{} {
+	result := make([]interface{}, len(scopes))
+	for i, scope := range scopes {
+		item := make(map[string]interface{})
+		item["scope"] = scope.Scope
+		item["description"] = scope.Description
+		item["requires_org"] = scope.requiresContextParam(CONNECTED_APP_SCOPE_CTX_ORG)
+		item["requires_env"] = scope.requiresContextParam(CONNECTED_APP_SCOPE_CTX_ENV)
+		result[i] = item
+	}
+	return result
+}
+
+func (s connectedAppScopeInfo) requiresContextParam(param string) bool {
+	return StringInSlice(s.ContextParams, param, true)
+}
+
+// loads the catalog of the scopes available for connected apps, indexed by scope
+func getConnectedAppScopesCatalog(ctx context.Context, pco *ProviderConfOutput) (map[string]connectedAppScopeInfo, error) {
+	authctx := getAccountsAuthCtx(ctx, pco)
+	var res connectedAppScopesResponse
+	httpr, err := pco.accountsclient.Execute(authctx, http.MethodGet, CONNECTED_APP_SCOPES_PATH, nil, &res)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get connected app scopes. %s", getHttpErrorDetails(httpr, err))
+	}
+	httpr.Body.Close()
+	catalog := make(map[string]connectedAppScopeInfo, len(res.Data))
+	for _, scope := range res.Data {
+		catalog[scope.Scope] = scope
+	}
+	return catalog, nil
+}
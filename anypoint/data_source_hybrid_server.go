@@ -0,0 +1,237 @@
+package anypoint
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+/*
+ * Runtime Manager hybrid api (on-premise mule runtimes).
+ * The organization and environment are given by the X-ANYPNT-ORG-ID and X-ANYPNT-ENV-ID headers.
+ */
+
+const (
+	HYBRID_SERVER_STATUS_RUNNING      = "RUNNING"
+	HYBRID_SERVER_STATUS_DISCONNECTED = "DISCONNECTED"
+	HYBRID_SERVER_STATUS_STOPPED      = "STOPPED"
+	HYBRID_SERVER_STATUS_CREATED      = "CREATED"
+)
+
+type hybridServerAddress struct {
+	Ip               string `json:"ip"`
+	NetworkInterface string `json:"networkInterface,omitempty"`
+}
+
+type hybridRef struct {
+	Id   int    `json:"id"`
+	Name string `json:"name,omitempty"`
+	Type string `json:"type,omitempty"`
+}
+
+type hybridServer struct {
+	Id           int                   `json:"id"`
+	Name         string                `json:"name"`
+	Type         string                `json:"type,omitempty"`
+	ServerType   string                `json:"serverType,omitempty"`
+	MuleVersion  string                `json:"muleVersion,omitempty"`
+	AgentVersion string                `json:"agentVersion,omitempty"`
+	Status       string                `json:"status,omitempty"`
+	ServerIp     string                `json:"serverIp,omitempty"`
+	TimeCreated  int64                 `json:"timeCreated,omitempty"`
+	TimeUpdated  int64                 `json:"timeUpdated,omitempty"`
+	Addresses    []hybridServerAddress `json:"addresses,omitempty"`
+	ServerGroup  *hybridRef            `json:"serverGroup,omitempty"`
+	Cluster      *hybridRef            `json:"cluster,omitempty"`
+}
+
+type hybridServerResponse struct {
+	Data hybridServer `json:"data"`
+}
+
+type hybridServersResponse struct {
+	Data  []hybridServer `json:"data"`
+	Total int            `json:"total,omitempty"`
+}
+
+// the schema of a hybrid server, shared by the server data sources
+var HybridServerReadOnlyDefinition = map[string]*schema.Schema{
+	"server_id": {
+		Type:        schema.TypeInt,
+		Computed:    true,
+		Description: "The id of the server.",
+	},
+	"name": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The name of the server.",
+	},
+	"server_type": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The type of the server, i.e. GATEWAY.",
+	},
+	"status": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The status of the server: RUNNING, DISCONNECTED, STOPPED or CREATED.",
+	},
+	"mule_version": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The version of the mule runtime.",
+	},
+	"agent_version": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The version of the runtime manager agent.",
+	},
+	"addresses": {
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "The ip addresses of the server.",
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	},
+	"server_group_id": {
+		Type:        schema.TypeInt,
+		Computed:    true,
+		Description: "The id of the server group the server belongs to, 0 if none.",
+	},
+	"cluster_id": {
+		Type:        schema.TypeInt,
+		Computed:    true,
+		Description: "The id of the cluster the server belongs to, 0 if none.",
+	},
+	"time_created": {
+		Type:        schema.TypeInt,
+		Computed:    true,
+		Description: "The registration time of the server (epoch in milliseconds).",
+	},
+	"time_updated": {
+		Type:        schema.TypeInt,
+		Computed:    true,
+		Description: "The last update time of the server (epoch in milliseconds).",
+	},
+}
+
+func dataSourceHybridServer() *schema.Resource {
+	readonly := make(map[string]*schema.Schema, len(HybridServerReadOnlyDefinition)+3)
+	for key, val := range HybridServerReadOnlyDefinition {
+		readonly[key] = val
+	}
+	readonly["org_id"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "The organization id where the server is registered.",
+	}
+	readonly["env_id"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "The environment id where the server is registered.",
+	}
+	readonly["server_id"] = &schema.Schema{
+		Type:        schema.TypeInt,
+		Required:    true,
+		Description: "The id of the server.",
+	}
+	return &schema.Resource{
+		ReadContext: dataSourceHybridServerRead,
+		Description: `
+		Reads a ` + "`" + `server` + "`" + ` registered in Runtime Manager (hybrid) and its status.
+		`,
+		Schema: readonly,
+	}
+}
+
+func dataSourceHybridServerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid := d.Get("org_id").(string)
+	envid := d.Get("env_id").(string)
+	serverid := d.Get("server_id").(int)
+	authctx := getHybridAuthCtx(ctx, &pco, orgid, envid)
+	//perform request
+	var res hybridServerResponse
+	httpr, err := pco.hybridclient.Execute(authctx, http.MethodGet, getHybridServerPath(serverid), nil, &res)
+	if err != nil {
+		var details string
+		if httpr != nil && httpr.StatusCode >= 400 {
+			defer httpr.Body.Close()
+			b, _ := io.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		diags := append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to read server " + strconv.Itoa(serverid),
+			Detail:   details,
+		})
+		return diags
+	}
+	defer httpr.Body.Close()
+	//process data
+	server := flattenHybridServer(&res.Data)
+	for key := range HybridServerReadOnlyDefinition {
+		if err := d.Set(key, server[key]); err != nil {
+			diags := append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to set server " + strconv.Itoa(serverid),
+				Detail:   err.Error(),
+			})
+			return diags
+		}
+	}
+	d.SetId(ComposeResourceId([]string{orgid, envid, strconv.Itoa(serverid)}))
+
+	return diags
+}
+
+func flattenHybridServer(server *hybridServer) map[string]interface{} {
+	item := make(map[string]interface{})
+	item["server_id"] = server.Id
+	item["name"] = server.Name
+	item["server_type"] = server.ServerType
+	item["status"] = server.Status
+	item["mule_version"] = server.MuleVersion
+	item["agent_version"] = server.AgentVersion
+	addresses := make([]string, len(server.Addresses))
+	for i, address := range server.Addresses {
+		addresses[i] = address.Ip
+	}
+	item["addresses"] = addresses
+	item["server_group_id"] = 0
+	if server.ServerGroup != nil {
+		item["server_group_id"] = server.ServerGroup.Id
+	}
+	item["cluster_id"] = 0
+	if server.Cluster != nil {
+		item["cluster_id"] = server.Cluster.Id
+	}
+	item["time_created"] = server.TimeCreated
+	item["time_updated"] = server.TimeUpdated
+	return item
+}
+
+func getHybridServerPath(serverid int) string {
+	return fmt.Sprintf("/servers/%d", serverid)
+}
+
+/*
+ * Returns authentication context (includes authorization header and the org and env headers)
+ */
+func getHybridAuthCtx(ctx context.Context, pco *ProviderConfOutput, orgid string, envid string) context.Context {
+	tmp := context.WithValue(ctx, RestContextAccessToken, pco.tokensource.Token(ctx))
+	tmp = context.WithValue(tmp, RestContextHeaders, map[string]string{
+		"X-ANYPNT-ORG-ID": orgid,
+		"X-ANYPNT-ENV-ID": envid,
+	})
+	return context.WithValue(tmp, RestContextServerIndex, pco.server_index)
+}
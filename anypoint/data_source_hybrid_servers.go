@@ -0,0 +1,108 @@
+package anypoint
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceHybridServers() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceHybridServersRead,
+		Description: `
+		Reads the ` + "`" + `servers` + "`" + ` registered in Runtime Manager (hybrid) for an environment, along with their status.
+		`,
+		Schema: map[string]*schema.Schema{
+			"org_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The organization id where the servers are registered.",
+			},
+			"env_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The environment id where the servers are registered.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Filters the servers by status: RUNNING, DISCONNECTED, STOPPED or CREATED. All servers are returned if not set.",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{
+					HYBRID_SERVER_STATUS_RUNNING, HYBRID_SERVER_STATUS_DISCONNECTED,
+					HYBRID_SERVER_STATUS_STOPPED, HYBRID_SERVER_STATUS_CREATED,
+				}, false)),
+			},
+			"servers": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The list of servers.",
+				Elem: &schema.Resource{
+					Schema: HybridServerReadOnlyDefinition,
+				},
+			},
+			"total": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The total number of servers returned.",
+			},
+		},
+	}
+}
+
+func dataSourceHybridServersRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid := d.Get("org_id").(string)
+	envid := d.Get("env_id").(string)
+	status := d.Get("status").(string)
+	authctx := getHybridAuthCtx(ctx, &pco, orgid, envid)
+	//perform request
+	var res hybridServersResponse
+	httpr, err := pco.hybridclient.Execute(authctx, http.MethodGet, "/servers", nil, &res)
+	if err != nil {
+		var details string
+		if httpr != nil && httpr.StatusCode >= 400 {
+			defer httpr.Body.Close()
+			b, _ := io.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		diags := append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to get servers of env " + envid,
+			Detail:   details,
+		})
+		return diags
+	}
+	defer httpr.Body.Close()
+	//process data
+	sort.SliceStable(res.Data, func(i, j int) bool {
+		return res.Data[i].Name < res.Data[j].Name
+	})
+	servers := make([]interface{}, 0, len(res.Data))
+	for _, server := range res.Data {
+		if status != "" && server.Status != status {
+			continue
+		}
+		servers = append(servers, flattenHybridServer(&server))
+	}
+	//save in data source schema
+	if err := d.Set("servers", servers); err != nil {
+		diags := append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to set servers of env " + envid,
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	d.Set("total", len(servers))
+	d.SetId(ComposeResourceId([]string{orgid, envid}))
+
+	return diags
+}
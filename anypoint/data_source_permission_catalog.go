@@ -0,0 +1,326 @@
+package anypoint
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	ROLE_CTX_PARAM_ORG = "org"
+	ROLE_CTX_PARAM_ENV = "envId"
+)
+
+const PERMISSION_CATALOG_PAGE_SIZE = 500
+
+type permissionCatalogRole struct {
+	RoleId        string   `json:"role_id"`
+	Name          string   `json:"name"`
+	Description   string   `json:"description,omitempty"`
+	Internal      bool     `json:"internal,omitempty"`
+	OrgId         string   `json:"org_id,omitempty"`
+	Namespaces    []string `json:"namespaces,omitempty"`
+	ContextParams []string `json:"context_params,omitempty"`
+}
+
+type permissionCatalogResponse struct {
+	Data  []permissionCatalogRole `json:"data"`
+	Total int                     `json:"total,omitempty"`
+}
+
+func dataSourcePermissionCatalog() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePermissionCatalogRead,
+		Description: `
+		Reads the catalog of ` + "`" + `roles` + "`" + ` (permissions) available in an org, with the context params each role requires when assigned.
+		The given role names are resolved to role ids.
+		`,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The id of the organization.",
+			},
+			"org_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The organization id where the roles are available.",
+			},
+			"names": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The names of the roles to resolve. Fails if one of the names is not found.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"role_ids": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The resolved role ids by name. Contains all the roles if names is not set.",
+			},
+			"roles": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The roles available in the organization.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"role_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The unique id of the role in the platform.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of this role.",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The description of the role.",
+						},
+						"internal": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether this role is intended for internal use only.",
+						},
+						"namespaces": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The list of namespaces related to this role.",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"requires_org": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the org context param is required when assigning this role.",
+						},
+						"requires_env": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the envId context param is required when assigning this role (environment scoped role).",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePermissionCatalogRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid := d.Get("org_id").(string)
+	//perform request
+	data, err := listPermissionCatalogRoles(ctx, &pco, orgid)
+	if err != nil {
+		diags := append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to get roles of org " + orgid,
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	//process data
+	sort.SliceStable(data, func(i, j int) bool {
+		return data[i].Name < data[j].Name
+	})
+	roles := make([]interface{}, len(data))
+	byname := make(map[string]interface{}, len(data))
+	for i, role := range data {
+		roles[i] = flattenPermissionCatalogRole(&role)
+		byname[role.Name] = role.RoleId
+	}
+	roleids := byname
+	if names := d.Get("names").([]interface{}); len(names) > 0 {
+		roleids = make(map[string]interface{}, len(names))
+		notfound := make([]string, 0)
+		for _, name := range names {
+			if id, ok := byname[name.(string)]; ok {
+				roleids[name.(string)] = id
+			} else {
+				notfound = append(notfound, name.(string))
+			}
+		}
+		if len(notfound) > 0 {
+			diags := append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to resolve roles of org " + orgid,
+				Detail:   "roles not found: " + strings.Join(notfound, ", "),
+			})
+			return diags
+		}
+	}
+	//save in data source schema
+	if err := d.Set("roles", roles); err != nil {
+		diags := append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to set roles of org " + orgid,
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	if err := d.Set("role_ids", roleids); err != nil {
+		diags := append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to set role ids of org " + orgid,
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	d.SetId(orgid)
+
+	return diags
+}
+
+func flattenPermissionCatalogRole(role *permissionCatalogRole) map[string]interface{} {
+	item := make(map[string]interface{})
+	item["role_id"] = role.RoleId
+	item["name"] = role.Name
+	item["description"] = role.Description
+	item["internal"] = role.Internal
+	item["namespaces"] = role.Namespaces
+	item["requires_org"] = role.requiresContextParam(ROLE_CTX_PARAM_ORG)
+	item["requires_env"] = role.requiresContextParam(ROLE_CTX_PARAM_ENV)
+	return item
+}
+
+// the org context param is required by all roles, envId only by environment scoped roles
+func (r permissionCatalogRole) requiresContextParam(param string) bool {
+	if param == ROLE_CTX_PARAM_ORG {
+		return true
+	}
+	return StringInSlice(r.ContextParams, param, true)
+}
+
+// loads the roles available in the given org, indexed by role id
+func getPermissionCatalog(ctx context.Context, pco *ProviderConfOutput, orgid string) (map[string]permissionCatalogRole, error) {
+	data, err := listPermissionCatalogRoles(ctx, pco, orgid)
+	if err != nil {
+		return nil, err
+	}
+	catalog := make(map[string]permissionCatalogRole, len(data))
+	for _, role := range data {
+		catalog[role.RoleId] = role
+	}
+	return catalog, nil
+}
+
+// lists all the roles available in the given org, paginating through the results
+func listPermissionCatalogRoles(ctx context.Context, pco *ProviderConfOutput, orgid string) ([]permissionCatalogRole, error) {
+	authctx := getAccountsAuthCtx(ctx, pco)
+	roles := make([]permissionCatalogRole, 0)
+	for offset := 0; ; offset += PERMISSION_CATALOG_PAGE_SIZE {
+		var res permissionCatalogResponse
+		httpr, err := pco.accountsclient.Execute(authctx, http.MethodGet, getPermissionCatalogPath(orgid, offset), nil, &res)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get roles of org %s. %s", orgid, getHttpErrorDetails(httpr, err))
+		}
+		httpr.Body.Close()
+		roles = append(roles, res.Data...)
+		if len(res.Data) < PERMISSION_CATALOG_PAGE_SIZE || (res.Total > 0 && len(roles) >= res.Total) {
+			break
+		}
+	}
+	return roles, nil
+}
+
+// validates the roles to assign against the permission catalog:
+// the roles should exist, the context params should be the ones required by the role and the referenced orgs should exist.
+// if envAllowed is false, environment scoped roles are rejected.
+func validateRolesContextParams(ctx context.Context, rd *schema.ResourceDiff, pco *ProviderConfOutput, orgid string, envAllowed bool) error {
+	roles := rd.Get("roles").([]interface{})
+	if len(roles) == 0 {
+		return nil
+	}
+	catalog, err := getPermissionCatalog(ctx, pco, orgid)
+	if err != nil {
+		log.Printf("[WARN] skipping roles validation: %s", err)
+		return nil
+	}
+	orgs := make(map[string]bool)
+	errs := make([]string, 0)
+	for i, item := range roles {
+		if item == nil || !rd.NewValueKnown(fmt.Sprintf("roles.%d.role_id", i)) {
+			continue
+		}
+		content := item.(map[string]interface{})
+		roleid := content["role_id"].(string)
+		role, ok := catalog[roleid]
+		if !ok {
+			errs = append(errs, fmt.Sprintf("role %s does not exist", roleid))
+			continue
+		}
+		if role.requiresContextParam(ROLE_CTX_PARAM_ENV) && !envAllowed {
+			errs = append(errs, fmt.Sprintf("role %s (%s) is environment scoped and cannot be assigned here", role.Name, roleid))
+			continue
+		}
+		params, ok := content["context_params"].(map[string]interface{})
+		if !ok || !envAllowed {
+			continue
+		}
+		for key := range params {
+			if key != ROLE_CTX_PARAM_ORG && key != ROLE_CTX_PARAM_ENV {
+				errs = append(errs, fmt.Sprintf("role %s (%s) has an unsupported context param %s", role.Name, roleid, key))
+			}
+		}
+		for _, param := range []string{ROLE_CTX_PARAM_ORG, ROLE_CTX_PARAM_ENV} {
+			if !rd.NewValueKnown(fmt.Sprintf("roles.%d.context_params.%s", i, param)) {
+				continue
+			}
+			val, _ := params[param].(string)
+			if role.requiresContextParam(param) && val == "" {
+				errs = append(errs, fmt.Sprintf("role %s (%s) requires the context param %s", role.Name, roleid, param))
+			}
+			if !role.requiresContextParam(param) && val != "" {
+				errs = append(errs, fmt.Sprintf("role %s (%s) is not environment scoped, the context param %s is not allowed", role.Name, roleid, param))
+			}
+			if param == ROLE_CTX_PARAM_ORG && val != "" {
+				if _, checked := orgs[val]; !checked {
+					exists, err := existsPermissionCatalogOrg(ctx, pco, val)
+					if err != nil {
+						log.Printf("[WARN] skipping org %s validation: %s", val, err)
+						exists = true
+					}
+					orgs[val] = exists
+				}
+				if !orgs[val] {
+					errs = append(errs, fmt.Sprintf("role %s (%s) references org %s which does not exist", role.Name, roleid, val))
+				}
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid roles, see the data source anypoint_permission_catalog for the available roles:\n\t%s", strings.Join(errs, "\n\t"))
+	}
+	return nil
+}
+
+// returns true if the given organization exists
+func existsPermissionCatalogOrg(ctx context.Context, pco *ProviderConfOutput, orgid string) (bool, error) {
+	authctx := getBGAuthCtx(ctx, pco)
+	_, httpr, err := pco.orgclient.DefaultApi.OrganizationsOrgIdGet(authctx, orgid).Execute()
+	if err != nil {
+		if httpr != nil && httpr.StatusCode == http.StatusNotFound {
+			return false, nil
+		}
+		return false, fmt.Errorf("unable to get org %s. %s", orgid, getHttpErrorDetails(httpr, err))
+	}
+	httpr.Body.Close()
+	return true, nil
+}
+
+func getPermissionCatalogPath(orgid string, offset int) string {
+	return fmt.Sprintf("/organizations/%s/roles?offset=%d&limit=%d", url.PathEscape(orgid), offset, PERMISSION_CATALOG_PAGE_SIZE)
+}
@@ -432,3 +432,17 @@ func getSgKeystoreAttributes() []string {
 func isSgKeystorePEM(t string) bool {
 	return t == "PEM"
 }
+
+// returns the file extension matching the given keystore or truststore type
+func getSgFileExtension(t string) string {
+	switch t {
+	case "JKS":
+		return ".jks"
+	case "JCEKS":
+		return ".jceks"
+	case "PKCS12":
+		return ".p12"
+	default:
+		return ".pem"
+	}
+}
@@ -0,0 +1,150 @@
+package anypoint
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceSecretGroupSharedSecret() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceSecretGroupSharedSecretRead,
+		Description: `
+		Query a specific shared secret for a secret-group in a given organization and environment.
+		Secret values are not returned by the platform.
+		`,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Id assigned to this shared secret",
+			},
+			"sg_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The secret-group id where the shared secret instance is defined.",
+			},
+			"org_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The organization id where the shared secret's secret group is defined.",
+			},
+			"env_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The environment id where the shared secret's secret group is defined.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the shared secret",
+			},
+			"expiration_date": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The expiration date of the shared secret",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The specific type of the shared secret. Either UsernamePassword, SymmetricKey, S3Credential or Blob",
+			},
+			"path": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "path of this secret, relative to the containing secret group",
+			},
+			"username": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The username, only in the case of UsernamePassword type",
+			},
+			"access_key_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The access key id, only in the case of S3Credential type",
+			},
+		},
+	}
+}
+
+func dataSourceSecretGroupSharedSecretRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid := d.Get("org_id").(string)
+	envid := d.Get("env_id").(string)
+	sgid := d.Get("sg_id").(string)
+	id := d.Get("id").(string)
+	authctx := getSecretsManagerAuthCtx(ctx, &pco)
+	//perform request
+	var res sgSharedSecret
+	httpr, err := pco.secretsmanagerclient.Execute(authctx, http.MethodGet, getSgSharedSecretPath(orgid, envid, sgid, id), nil, &res)
+	if err != nil {
+		var details string
+		if httpr != nil && httpr.StatusCode >= 400 {
+			defer httpr.Body.Close()
+			b, _ := io.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to get shared secret " + id,
+			Detail:   details,
+		})
+		return diags
+	}
+	defer httpr.Body.Close()
+	//process result
+	data := flattenSgSharedSecret(&res)
+	if err := setSgSharedSecretAttributesToResourceData(d, data, getSgSharedSecretAttributes()); err != nil {
+		diags := append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to set shared secret " + id + " details attributes",
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	d.SetId(id)
+
+	return diags
+}
+
+// flattens the shared secret, secret values are omitted
+func flattenSgSharedSecret(secret *sgSharedSecret) map[string]interface{} {
+	item := make(map[string]interface{})
+	item["name"] = secret.Name
+	item["type"] = secret.Type
+	item["expiration_date"] = secret.ExpirationDate
+	item["username"] = secret.Username
+	item["access_key_id"] = secret.AccessKeyId
+	if secret.Meta != nil {
+		item["path"] = secret.Meta.Path
+	}
+	return item
+}
+
+func setSgSharedSecretAttributesToResourceData(d *schema.ResourceData, data map[string]interface{}, attributes []string) error {
+	if data != nil {
+		for _, attr := range attributes {
+			if val, ok := data[attr]; ok {
+				if err := d.Set(attr, val); err != nil {
+					return fmt.Errorf("unable to set shared secret attribute %s\n\tdetails: %s", attr, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func getSgSharedSecretAttributes() []string {
+	attributes := [...]string{
+		"name", "expiration_date", "type", "path", "username", "access_key_id",
+	}
+	return attributes[:]
+}
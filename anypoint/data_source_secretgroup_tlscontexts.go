@@ -157,6 +157,6 @@ func flattenSgTlsContextMeta(meta *secretgroup_tlscontext.Meta) map[string]inter
  * Returns authentication context (includes authorization header)
  */
 func getSgTlsContextAuthCtx(ctx context.Context, pco *ProviderConfOutput) context.Context {
-	tmp := context.WithValue(ctx, secretgroup_tlscontext.ContextAccessToken, pco.access_token)
+	tmp := context.WithValue(ctx, secretgroup_tlscontext.ContextAccessToken, pco.tokensource.Token(ctx))
 	return context.WithValue(tmp, secretgroup_tlscontext.ContextServerIndex, pco.server_index)
 }
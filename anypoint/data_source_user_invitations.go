@@ -0,0 +1,148 @@
+package anypoint
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceUserInvitations() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceUserInvitationsRead,
+		Description: `
+		Reads the invitations of your org that have not been accepted yet.
+		`,
+		Schema: map[string]*schema.Schema{
+			"org_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The organization id where the invitations are sent.",
+			},
+			"status": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "Filters the invitations by status. Either pending or expired. All invitations are returned if not set.",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{USER_INVITE_STATUS_PENDING, USER_INVITE_STATUS_EXPIRED}, false)),
+			},
+			"invitations": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The list of invitations",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"invite_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The id of the invitation.",
+						},
+						"email": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The email address the invitation is sent to.",
+						},
+						"inviter_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The id of the user who sent the invitation.",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The status of the invitation. Either pending or expired.",
+						},
+						"created_at": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time the invitation was sent.",
+						},
+						"expires_at": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time the invitation expires.",
+						},
+						"teams": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The teams the user is assigned to once the invitation is accepted.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"team_id": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The id of the team.",
+									},
+									"membership_type": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "Whether the user is a regular member or a maintainer of the team.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"total": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The total number of invitations returned.",
+			},
+		},
+	}
+}
+
+func dataSourceUserInvitationsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid := d.Get("org_id").(string)
+	status := d.Get("status").(string)
+	authctx := getAccountsAuthCtx(ctx, &pco)
+	//perform request
+	var res userInvitesResponse
+	httpr, err := pco.accountsclient.Execute(authctx, http.MethodGet, getUserInvitesPath(orgid), nil, &res)
+	if err != nil {
+		var details string
+		if httpr != nil && httpr.StatusCode >= 400 {
+			defer httpr.Body.Close()
+			b, _ := io.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		diags := append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to get invitations of org " + orgid,
+			Detail:   details,
+		})
+		return diags
+	}
+	defer httpr.Body.Close()
+	//process data
+	invitations := make([]interface{}, 0, len(res.Data))
+	for _, invite := range res.Data {
+		item := flattenUserInviteData(&invite)
+		if status != "" && item["status"] != status {
+			continue
+		}
+		invitations = append(invitations, item)
+	}
+	//save in data source schema
+	if err := d.Set("invitations", invitations); err != nil {
+		diags := append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to set invitations of org " + orgid,
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	d.Set("total", len(invitations))
+	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+
+	return diags
+}
@@ -0,0 +1,61 @@
+package anypoint
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+/*
+ * Deletion protection of the critical resources.
+ * The deletion_protection attribute is only stored in the state, the Delete functions refuse to proceed while it is enabled.
+ * It has to be set to false in an apply before the resource can be destroyed (or replaced).
+ */
+
+// returns an error if the deletion protection of the resource is enabled, nil otherwise
+func checkDeletionProtection(d *schema.ResourceData, summary string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if !d.Get("deletion_protection").(bool) {
+		return nil
+	}
+	diags = append(diags, diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  summary,
+		Detail:   "deletion_protection is enabled. Set deletion_protection to false and apply before destroying or replacing this resource.",
+	})
+	return diags
+}
+
+// enables the deletion protection by default when the environment type is one of the provider's protected_environment_types.
+// does nothing if deletion_protection is set in the configuration.
+func customizeDiffDeletionProtection(rd *schema.ResourceDiff, pco *ProviderConfOutput, envtype string) error {
+	raw := rd.GetRawConfig()
+	if raw.IsNull() || !raw.GetAttr("deletion_protection").IsNull() {
+		return nil
+	}
+	protected := isProtectedEnvironmentType(pco, envtype)
+	if rd.Get("deletion_protection").(bool) != protected {
+		return rd.SetNew("deletion_protection", protected)
+	}
+	return nil
+}
+
+// applies the default deletion protection of a resource defined in the environment given by its org_id and env_id
+func customizeDiffEnvDeletionProtection(ctx context.Context, rd *schema.ResourceDiff, pco *ProviderConfOutput) error {
+	if len(pco.defaults.protected_env_types) == 0 {
+		return customizeDiffDeletionProtection(rd, pco, "")
+	}
+	if !rd.NewValueKnown("org_id") || !rd.NewValueKnown("env_id") {
+		return rd.SetNewComputed("deletion_protection")
+	}
+	orgid := rd.Get("org_id").(string)
+	envid := rd.Get("env_id").(string)
+	envtype, err := getEnvironmentType(ctx, pco, orgid, envid)
+	if err != nil {
+		log.Printf("[WARN] skipping default deletion protection: %s", err)
+		return nil
+	}
+	return customizeDiffDeletionProtection(rd, pco, envtype)
+}
@@ -0,0 +1,195 @@
+package anypoint
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+/*
+ * Local application artifacts.
+ * The deployment resources accepting an artifact_path keep the sha256 checksum of the file in artifact_hash,
+ * so a change of the content of the file is detected at plan time.
+ * The Cloudhub 2.0 and Runtime Fabrics deployments publish the artifact to Exchange before deploying it.
+ */
+
+const (
+	EXCHANGE_MULE_APPLICATION_CLASSIFIER = "mule-application"
+	EXCHANGE_MULE_APPLICATION_PACKAGING  = "jar"
+)
+
+type exchangeAssetFile struct {
+	Classifier string `json:"classifier"`
+	Packaging  string `json:"packaging"`
+	Sha1       string `json:"sha1,omitempty"`
+	Md5        string `json:"md5,omitempty"`
+}
+
+type exchangeAsset struct {
+	GroupId string              `json:"groupId"`
+	AssetId string              `json:"assetId"`
+	Version string              `json:"version"`
+	Type    string              `json:"type,omitempty"`
+	Files   []exchangeAssetFile `json:"files,omitempty"`
+}
+
+// computes the checksum of the local artifact so a change of its content triggers a redeployment.
+// to be used in the CustomizeDiff of the resources having artifact_path and artifact_hash attributes.
+func customizeDiffArtifactHash(rd *schema.ResourceDiff) error {
+	if !rd.NewValueKnown("artifact_path") {
+		return rd.SetNewComputed("artifact_hash")
+	}
+	path := rd.Get("artifact_path").(string)
+	if path == "" {
+		if rd.Get("artifact_hash").(string) != "" {
+			return rd.SetNew("artifact_hash", "")
+		}
+		return nil
+	}
+	hash, err := hashArtifactFile(path, sha256.New())
+	if err != nil {
+		return err
+	}
+	if rd.Get("artifact_hash").(string) != hash {
+		return rd.SetNew("artifact_hash", hash)
+	}
+	return nil
+}
+
+// returns the hex encoded checksum of the given file using the given hash function
+func hashArtifactFile(path string, h hash.Hash) (string, error) {
+	f, err := openArtifactFile(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("unable to read artifact %s: %s", path, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// opens the local artifact found at the given path, a leading ~ is replaced by the user's home directory
+func openArtifactFile(path string) (*os.File, error) {
+	p, err := expandHomePath(path)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open artifact %s: %s", path, err)
+	}
+	return f, nil
+}
+
+// publishes the given local mule application jar to exchange under the given coordinates.
+// exchange versions are immutable: nothing is published if the version already exists with the same content,
+// an error is returned if it exists with a different content.
+func publishExchangeMuleApplication(ctx context.Context, pco *ProviderConfOutput, groupid, assetid, version, path string) error {
+	checksum, err := hashArtifactFile(path, sha1.New())
+	if err != nil {
+		return err
+	}
+	asset, err := getExchangeAsset(ctx, pco, groupid, assetid, version)
+	if err != nil {
+		return err
+	}
+	if asset != nil {
+		for _, file := range asset.Files {
+			if file.Classifier == EXCHANGE_MULE_APPLICATION_CLASSIFIER && file.Sha1 == checksum {
+				return nil
+			}
+		}
+		return fmt.Errorf("version %s of %s:%s is already published in exchange with a different content. Exchange versions are immutable, increment the version to publish the new artifact", version, groupid, assetid)
+	}
+	//prepare the multipart body
+	f, err := openArtifactFile(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	if err := w.WriteField("name", assetid); err != nil {
+		return err
+	}
+	field := fmt.Sprintf("files.%s.%s", EXCHANGE_MULE_APPLICATION_CLASSIFIER, EXCHANGE_MULE_APPLICATION_PACKAGING)
+	part, err := w.CreateFormFile(field, filepath.Base(path))
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(part, f); err != nil {
+		return fmt.Errorf("unable to read artifact %s: %s", path, err)
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	//perform request, the publication is synchronous so the asset can be deployed right away
+	authctx := getExchangeAuthCtx(ctx, pco)
+	authctx = context.WithValue(authctx, RestContextHeaders, map[string]string{"x-sync-publication": "true"})
+	httpr, err := pco.exchangeclient.ExecuteRaw(authctx, http.MethodPost, getExchangeAssetPublicationPath(groupid, assetid, version), &buf, w.FormDataContentType(), nil)
+	if err != nil {
+		return fmt.Errorf("unable to publish %s:%s:%s to exchange. %s", groupid, assetid, version, getHttpErrorDetails(httpr, err))
+	}
+	httpr.Body.Close()
+	return nil
+}
+
+// returns the given asset version, nil if it doesn't exist
+func getExchangeAsset(ctx context.Context, pco *ProviderConfOutput, groupid, assetid, version string) (*exchangeAsset, error) {
+	authctx := getExchangeAuthCtx(ctx, pco)
+	var res exchangeAsset
+	httpr, err := pco.exchangeclient.Execute(authctx, http.MethodGet, getExchangeAssetPath(groupid, assetid, version), nil, &res)
+	if err != nil {
+		if httpr != nil && httpr.StatusCode == http.StatusNotFound {
+			httpr.Body.Close()
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to get exchange asset %s:%s:%s. %s", groupid, assetid, version, getHttpErrorDetails(httpr, err))
+	}
+	httpr.Body.Close()
+	return &res, nil
+}
+
+func getExchangeAssetPath(groupid, assetid, version string) string {
+	return fmt.Sprintf("/assets/%s/%s/%s", url.PathEscape(groupid), url.PathEscape(assetid), url.PathEscape(version))
+}
+
+// the group id of an asset is the id of the organization (or business group) publishing it
+func getExchangeAssetPublicationPath(groupid, assetid, version string) string {
+	return fmt.Sprintf("/organizations/%s/assets/%s/%s/%s",
+		url.PathEscape(groupid), url.PathEscape(groupid), url.PathEscape(assetid), url.PathEscape(version),
+	)
+}
+
+/*
+ * Returns authentication context (includes authorization header)
+ */
+func getExchangeAuthCtx(ctx context.Context, pco *ProviderConfOutput) context.Context {
+	tmp := context.WithValue(ctx, RestContextAccessToken, pco.tokensource.Token(ctx))
+	return context.WithValue(tmp, RestContextServerIndex, pco.server_index)
+}
+
+// publishes the local artifact of a Cloudhub 2.0 or Runtime Fabrics deployment (if any) under the coordinates of its application ref
+func publishAppDeploymentV2Artifact(ctx context.Context, pco *ProviderConfOutput, d *schema.ResourceData) error {
+	path := d.Get("artifact_path").(string)
+	if path == "" {
+		return nil
+	}
+	groupid := d.Get("application.0.ref.0.group_id").(string)
+	assetid := d.Get("application.0.ref.0.artifact_id").(string)
+	version := d.Get("application.0.ref.0.version").(string)
+	return publishExchangeMuleApplication(ctx, pco, groupid, assetid, version, path)
+}
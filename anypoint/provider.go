@@ -4,9 +4,13 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"log"
+	"os"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	auth "github.com/mulesoft-anypoint/anypoint-client-go/authorization"
 )
@@ -36,6 +40,75 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("ANYPOINT_ACCESS_TOKEN", nil),
 				Description: "the connected app's access token",
 			},
+			"refresh_token": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("ANYPOINT_REFRESH_TOKEN", nil),
+				Description: "a refresh token obtained with the connected app's authorization code grant. Requires client_id and client_secret.",
+			},
+			"private_key": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				DefaultFunc:   schema.EnvDefaultFunc("ANYPOINT_PRIVATE_KEY", nil),
+				ConflictsWith: []string{"private_key_file"},
+				Description:   "the PEM encoded RSA private key (unencrypted PKCS#1 or PKCS#8) used to sign the assertions of the connected app's jwt bearer grant. Requires client_id and jwt_subject.",
+			},
+			"private_key_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("ANYPOINT_PRIVATE_KEY_FILE", nil),
+				ConflictsWith: []string{"private_key"},
+				Description:   "the path of the file containing the private key used for the jwt bearer grant, alternative to private_key.",
+			},
+			"jwt_subject": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ANYPOINT_JWT_SUBJECT", nil),
+				Description: "the username of the user the connected app acts on behalf of when using the jwt bearer grant.",
+			},
+			"credentials_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ANYPOINT_CREDENTIALS_FILE", nil),
+				Description: "the path of an anypoint-cli like credentials file, used when no credentials are set in the provider configuration. Defaults to " + CREDENTIALS_FILE_DEFAULT_PATH + ".",
+			},
+			"profile": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ANYPOINT_PROFILE", CREDENTIALS_PROFILE_DEFAULT),
+				Description: "the profile of the credentials file to use.",
+			},
+			"default_org_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ANYPOINT_DEFAULT_ORG_ID", nil),
+				Description: "the organization id used by the resources that do not set org_id",
+			},
+			"default_env_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("ANYPOINT_DEFAULT_ENV_ID", nil),
+				ConflictsWith: []string{"default_env_name"},
+				Description:   "the environment id used by the resources that do not set env_id",
+			},
+			"default_env_name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("ANYPOINT_DEFAULT_ENV_NAME", nil),
+				ConflictsWith: []string{"default_env_id"},
+				Description:   "the name of the environment used by the resources that do not set env_id. The name is resolved in the organization of each resource.",
+			},
+			"protected_environment_types": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"sandbox", "design", "production"}, true)),
+				},
+				Description: "the environment types (i.e. production) whose environments and secret groups have deletion_protection enabled by default",
+			},
 			"username": {
 				Type:        schema.TypeString,
 				Deprecated:  "Remove this attribute's configuration as it no longer is used and the attribute will be removed in the next major version of the provider.",
@@ -77,39 +150,67 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
-	client_id := d.Get("client_id").(string)
-	client_secret := d.Get("client_secret").(string)
-	access_token := d.Get("access_token").(string)
-	//Deprecated
-	username := d.Get("username").(string)
-	password := d.Get("password").(string)
 	cplane := d.Get("cplane").(string)
-
+	cfg := newProviderAuthConfig(d)
+	methods := cfg.methods()
+	// the credentials file is only used when no credentials are configured
+	if len(methods) == 0 {
+		profile := d.Get("profile").(string)
+		pcfg, err := loadCredentialsFileProfile(d.Get("credentials_file").(string), profile)
+		if err != nil {
+			diags := append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to load credentials profile " + profile,
+				Detail:   err.Error(),
+			})
+			return newProviderConfOutput(newStaticTokenSource(""), cplane2serverindex(cplane)), diags
+		}
+		if pcfg != nil {
+			cfg = pcfg
+			methods = cfg.methods()
+			// the profile's host is used unless a control plane is configured
+			raw := d.GetRawConfig()
+			if cfg.Host != "" && (raw.IsNull() || raw.GetAttr("cplane").IsNull()) && os.Getenv("ANYPOINT_CPLANE") == "" {
+				cplane = host2cplane(cfg.Host)
+			}
+		}
+	}
 	server_index := cplane2serverindex(cplane)
-	auth_ctx := context.WithValue(ctx, auth.ContextServerIndex, server_index)
 
-	if access_token != "" {
-		return newProviderConfOutput(access_token, server_index), diags
+	if len(methods) == 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "No authentication method configured",
+			Detail:   "Set either access_token, client_id and client_secret, client_id with private_key and jwt_subject, client_id and client_secret with refresh_token, or use a credentials file profile.",
+		})
+		pco := newProviderConfOutput(newStaticTokenSource(""), server_index)
+		pco.defaults = newProviderDefaults(d)
+		return pco, diags
 	}
-
-	if (username != "") && (password != "") {
-		authres, d := userPwdAuth(auth_ctx, username, password)
-		if d != nil {
-			return newProviderConfOutput("", server_index), d
-		}
-		return newProviderConfOutput(authres.GetAccessToken(), server_index), diags
+	method := methods[0]
+	if len(methods) > 1 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Several authentication methods configured",
+			Detail:   fmt.Sprintf("Using %s from the %s. Ignored: %s.", method, cfg.source, strings.Join(methods[1:], ", ")),
+		})
 	}
-
-	if (client_id != "") && (client_secret != "") {
-		authres, d := connectedAppAuth(auth_ctx, client_id, client_secret)
-		if d != nil {
-			return newProviderConfOutput("", server_index), d
-		}
-		return newProviderConfOutput(authres.GetAccessToken(), server_index), diags
+	if missing := cfg.missingAttributes(method); len(missing) > 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Incomplete authentication configuration",
+			Detail:   fmt.Sprintf("The %s authentication method from the %s requires: %s.", method, cfg.source, strings.Join(missing, ", ")),
+		})
+		return newProviderConfOutput(newStaticTokenSource(""), server_index), diags
 	}
+	log.Printf("[INFO] authenticating using %s from the %s", method, cfg.source)
+	tokensource, authdiags := authenticateProvider(ctx, cfg, method, server_index)
+	diags = append(diags, authdiags...)
 
-	return newProviderConfOutput("", server_index), diags
+	pco := newProviderConfOutput(tokensource, server_index)
+	pco.defaults = newProviderDefaults(d)
 
+	return pco, diags
 }
 
 /*
@@ -0,0 +1,418 @@
+package anypoint
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	auth "github.com/mulesoft-anypoint/anypoint-client-go/authorization"
+)
+
+/*
+ * Provider authentication methods.
+ * The credentials are read from the provider configuration (or their environment variables).
+ * If none is configured, they are read from a profile of an anypoint-cli like credentials file.
+ * When several methods are configured, the first one is used in the following order:
+ * access_token, jwt_bearer, refresh_token, username_password, client_credentials
+ */
+
+const (
+	AUTH_METHOD_ACCESS_TOKEN       = "access_token"
+	AUTH_METHOD_JWT_BEARER         = "jwt_bearer"
+	AUTH_METHOD_REFRESH_TOKEN      = "refresh_token"
+	AUTH_METHOD_USER_PASSWORD      = "username_password"
+	AUTH_METHOD_CLIENT_CREDENTIALS = "client_credentials"
+)
+
+const (
+	OAUTH2_TOKEN_PATH          = "/api/v2/oauth2/token"
+	OAUTH2_GRANT_JWT_BEARER    = "urn:ietf:params:oauth:grant-type:jwt-bearer"
+	OAUTH2_GRANT_REFRESH_TOKEN = "refresh_token"
+	// the validity of the signed assertions
+	JWT_ASSERTION_LIFETIME = 5 * time.Minute
+	// the credentials file used by the anypoint-cli
+	CREDENTIALS_FILE_DEFAULT_PATH = "~/.anypoint/credentials"
+	CREDENTIALS_PROFILE_DEFAULT   = "default"
+)
+
+// the credentials of the provider, either from the provider configuration or from a credentials file profile.
+// the json tags are the keys of a credentials file profile.
+type providerAuthConfig struct {
+	source         string
+	ClientId       string `json:"client_id,omitempty"`
+	ClientSecret   string `json:"client_secret,omitempty"`
+	AccessToken    string `json:"access_token,omitempty"`
+	RefreshToken   string `json:"refresh_token,omitempty"`
+	PrivateKey     string `json:"private_key,omitempty"`
+	PrivateKeyFile string `json:"private_key_file,omitempty"`
+	JwtSubject     string `json:"jwt_subject,omitempty"`
+	Username       string `json:"username,omitempty"`
+	Password       string `json:"password,omitempty"`
+	Host           string `json:"host,omitempty"`
+}
+
+type oauth2TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type,omitempty"`
+	ExpiresIn    int    `json:"expires_in,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+}
+
+// returns the lifetime of the access token, 0 if not provided
+func (r *oauth2TokenResponse) lifetime() time.Duration {
+	return time.Duration(r.ExpiresIn) * time.Second
+}
+
+// loads the credentials set in the provider configuration
+func newProviderAuthConfig(d *schema.ResourceData) *providerAuthConfig {
+	return &providerAuthConfig{
+		source:         "provider configuration",
+		ClientId:       d.Get("client_id").(string),
+		ClientSecret:   d.Get("client_secret").(string),
+		AccessToken:    d.Get("access_token").(string),
+		RefreshToken:   d.Get("refresh_token").(string),
+		PrivateKey:     d.Get("private_key").(string),
+		PrivateKeyFile: d.Get("private_key_file").(string),
+		JwtSubject:     d.Get("jwt_subject").(string),
+		Username:       d.Get("username").(string),
+		Password:       d.Get("password").(string),
+	}
+}
+
+// returns the configured authentication methods in order of precedence
+func (c *providerAuthConfig) methods() []string {
+	methods := make([]string, 0)
+	haskey := c.PrivateKey != "" || c.PrivateKeyFile != ""
+	if c.AccessToken != "" {
+		methods = append(methods, AUTH_METHOD_ACCESS_TOKEN)
+	}
+	if haskey {
+		methods = append(methods, AUTH_METHOD_JWT_BEARER)
+	}
+	if c.RefreshToken != "" {
+		methods = append(methods, AUTH_METHOD_REFRESH_TOKEN)
+	}
+	if c.Username != "" || c.Password != "" {
+		methods = append(methods, AUTH_METHOD_USER_PASSWORD)
+	}
+	// the connected app credentials are also used by the jwt bearer and refresh token methods
+	if (c.ClientId != "" || c.ClientSecret != "") && !haskey && c.RefreshToken == "" {
+		methods = append(methods, AUTH_METHOD_CLIENT_CREDENTIALS)
+	}
+	return methods
+}
+
+// returns the attributes missing for the given authentication method
+func (c *providerAuthConfig) missingAttributes(method string) []string {
+	missing := make([]string, 0)
+	switch method {
+	case AUTH_METHOD_JWT_BEARER:
+		if c.ClientId == "" {
+			missing = append(missing, "client_id")
+		}
+		if c.JwtSubject == "" {
+			missing = append(missing, "jwt_subject")
+		}
+	case AUTH_METHOD_REFRESH_TOKEN, AUTH_METHOD_CLIENT_CREDENTIALS:
+		if c.ClientId == "" {
+			missing = append(missing, "client_id")
+		}
+		if c.ClientSecret == "" {
+			missing = append(missing, "client_secret")
+		}
+	case AUTH_METHOD_USER_PASSWORD:
+		if c.Username == "" {
+			missing = append(missing, "username")
+		}
+		if c.Password == "" {
+			missing = append(missing, "password")
+		}
+	}
+	return missing
+}
+
+// loads the credentials of the given profile from the credentials file.
+// returns nil if the file is the default one and does not exist.
+func loadCredentialsFileProfile(path string, profile string) (*providerAuthConfig, error) {
+	explicit := path != ""
+	if !explicit {
+		path = CREDENTIALS_FILE_DEFAULT_PATH
+	}
+	path, err := expandHomePath(path)
+	if err != nil {
+		return nil, err
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if !explicit && errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to read credentials file %s. %s", path, err)
+	}
+	profiles := make(map[string]*providerAuthConfig)
+	if err := json.Unmarshal(b, &profiles); err != nil {
+		return nil, fmt.Errorf("unable to parse credentials file %s. %s", path, err)
+	}
+	cfg, ok := profiles[profile]
+	if !ok || cfg == nil {
+		if !explicit && profile == CREDENTIALS_PROFILE_DEFAULT {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("profile %s not found in credentials file %s", profile, path)
+	}
+	cfg.source = fmt.Sprintf("profile %s of credentials file %s", profile, path)
+	if cfg.PrivateKeyFile != "" {
+		// a relative key file is relative to the credentials file
+		keyfile, err := expandHomePath(cfg.PrivateKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		if !filepath.IsAbs(keyfile) {
+			keyfile = filepath.Join(filepath.Dir(path), keyfile)
+		}
+		cfg.PrivateKeyFile = keyfile
+	}
+	return cfg, nil
+}
+
+// replaces the leading ~ of the given path by the user's home directory
+func expandHomePath(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("unable to expand path %s. %s", path, err)
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~")), nil
+}
+
+// returns the control plane of the given anypoint host, i.e. eu1.anypoint.mulesoft.com
+func host2cplane(host string) string {
+	host = strings.TrimPrefix(strings.ToLower(host), "https://")
+	if strings.HasPrefix(host, "eu1.") {
+		return "eu"
+	} else if strings.HasPrefix(host, "gov.") {
+		return "gov"
+	}
+	return "us"
+}
+
+// authenticates using the given method and returns the token source delivering the access tokens
+func authenticateProvider(ctx context.Context, cfg *providerAuthConfig, method string, server_index int) (*tokenSource, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var refresh tokenRefreshFunc
+	var summary string
+	switch method {
+	case AUTH_METHOD_ACCESS_TOKEN:
+		return newStaticTokenSource(cfg.AccessToken), diags
+	case AUTH_METHOD_JWT_BEARER:
+		summary = "Unable to Authenticate Using JWT Bearer"
+		key, err := loadJwtPrivateKey(cfg.PrivateKey, cfg.PrivateKeyFile)
+		if err != nil {
+			diags := append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  summary,
+				Detail:   err.Error(),
+			})
+			return newStaticTokenSource(""), diags
+		}
+		refresh = func(ctx context.Context) (string, time.Duration, error) {
+			res, err := jwtBearerAuth(ctx, server_index, cfg.ClientId, cfg.ClientSecret, cfg.JwtSubject, key)
+			if err != nil {
+				return "", 0, err
+			}
+			return res.AccessToken, res.lifetime(), nil
+		}
+	case AUTH_METHOD_REFRESH_TOKEN:
+		summary = "Unable to Authenticate Using Refresh Token"
+		refresh = func(ctx context.Context) (string, time.Duration, error) {
+			res, err := refreshTokenAuth(ctx, server_index, cfg.ClientId, cfg.ClientSecret, cfg.RefreshToken)
+			if err != nil {
+				return "", 0, err
+			}
+			// the refresh token may be rotated
+			if res.RefreshToken != "" {
+				cfg.RefreshToken = res.RefreshToken
+			}
+			return res.AccessToken, res.lifetime(), nil
+		}
+	case AUTH_METHOD_USER_PASSWORD:
+		summary = "Unable to Authenticate Using User Password"
+		refresh = func(ctx context.Context) (string, time.Duration, error) {
+			authres, d := userPwdAuth(context.WithValue(ctx, auth.ContextServerIndex, server_index), cfg.Username, cfg.Password)
+			if d.HasError() {
+				return "", 0, errors.New(d[0].Detail)
+			}
+			return authres.GetAccessToken(), 0, nil
+		}
+	case AUTH_METHOD_CLIENT_CREDENTIALS:
+		summary = "Unable to Authenticate Using Connected App"
+		refresh = func(ctx context.Context) (string, time.Duration, error) {
+			authres, d := connectedAppAuth(context.WithValue(ctx, auth.ContextServerIndex, server_index), cfg.ClientId, cfg.ClientSecret)
+			if d.HasError() {
+				return "", 0, errors.New(d[0].Detail)
+			}
+			return authres.GetAccessToken(), 0, nil
+		}
+	default:
+		diags := append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unsupported authentication method " + method,
+		})
+		return newStaticTokenSource(""), diags
+	}
+	token, lifetime, err := refresh(ctx)
+	if err != nil {
+		diags := append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  summary,
+			Detail:   err.Error(),
+		})
+		return newStaticTokenSource(""), diags
+	}
+	return newRefreshableTokenSource(token, lifetime, refresh), diags
+}
+
+/*
+Authenticates a connected app using the jwt bearer grant.
+The assertion is signed with the private key matching the certificate of the connected app.
+*/
+func jwtBearerAuth(ctx context.Context, server_index int, client_id string, client_secret string, subject string, key *rsa.PrivateKey) (*oauth2TokenResponse, error) {
+	tokenurl, err := getOAuth2TokenUrl(server_index)
+	if err != nil {
+		return nil, err
+	}
+	assertion, err := signJwtAssertion(key, client_id, subject, tokenurl)
+	if err != nil {
+		return nil, err
+	}
+	form := url.Values{}
+	form.Set("grant_type", OAUTH2_GRANT_JWT_BEARER)
+	form.Set("assertion", assertion)
+	form.Set("client_id", client_id)
+	if client_secret != "" {
+		form.Set("client_secret", client_secret)
+	}
+	return requestOAuth2Token(ctx, server_index, form)
+}
+
+/*
+Authenticates a connected app using a refresh token obtained with the authorization code grant
+*/
+func refreshTokenAuth(ctx context.Context, server_index int, client_id string, client_secret string, refresh_token string) (*oauth2TokenResponse, error) {
+	form := url.Values{}
+	form.Set("grant_type", OAUTH2_GRANT_REFRESH_TOKEN)
+	form.Set("refresh_token", refresh_token)
+	form.Set("client_id", client_id)
+	form.Set("client_secret", client_secret)
+	return requestOAuth2Token(ctx, server_index, form)
+}
+
+// posts the given form to the oauth2 token endpoint
+func requestOAuth2Token(ctx context.Context, server_index int, form url.Values) (*oauth2TokenResponse, error) {
+	client := NewRestAPIClient("/accounts", nil)
+	authctx := context.WithValue(ctx, RestContextServerIndex, server_index)
+	var res oauth2TokenResponse
+	httpr, err := client.ExecuteRaw(authctx, http.MethodPost, OAUTH2_TOKEN_PATH, strings.NewReader(form.Encode()), "application/x-www-form-urlencoded", &res)
+	if err != nil {
+		return nil, errors.New(getHttpErrorDetails(httpr, err))
+	}
+	httpr.Body.Close()
+	if res.AccessToken == "" {
+		return nil, fmt.Errorf("no access token returned")
+	}
+	return &res, nil
+}
+
+func getOAuth2TokenUrl(server_index int) (string, error) {
+	if server_index < 0 || server_index >= len(REST_CLIENT_SERVERS) {
+		return "", fmt.Errorf("invalid server index %d", server_index)
+	}
+	return REST_CLIENT_SERVERS[server_index] + "/accounts" + OAUTH2_TOKEN_PATH, nil
+}
+
+// loads the rsa private key either from its PEM content or from the given file
+func loadJwtPrivateKey(content string, file string) (*rsa.PrivateKey, error) {
+	if content == "" {
+		path, err := expandHomePath(file)
+		if err != nil {
+			return nil, err
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read private key file %s. %s", path, err)
+		}
+		content = string(b)
+	}
+	block, _ := pem.Decode([]byte(content))
+	if block == nil {
+		return nil, fmt.Errorf("no PEM encoded private key found")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse private key, an unencrypted PKCS#1 or PKCS#8 key is expected. %s", err)
+	}
+	rsakey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("only RSA private keys are supported")
+	}
+	return rsakey, nil
+}
+
+// returns a RS256 signed jwt issued by the connected app for the given subject (the username)
+func signJwtAssertion(key *rsa.PrivateKey, client_id string, subject string, audience string) (string, error) {
+	now := time.Now()
+	jti := make([]byte, 16)
+	if _, err := rand.Read(jti); err != nil {
+		return "", err
+	}
+	header := map[string]interface{}{
+		"alg": "RS256",
+		"typ": "JWT",
+	}
+	claims := map[string]interface{}{
+		"iss": client_id,
+		"sub": subject,
+		"aud": audience,
+		"iat": now.Unix(),
+		"exp": now.Add(JWT_ASSERTION_LIFETIME).Unix(),
+		"jti": hex.EncodeToString(jti),
+	}
+	hb, err := json.Marshal(header)
+	if err != nil {
+		return "", err
+	}
+	cb, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	unsigned := base64.RawURLEncoding.EncodeToString(hb) + "." + base64.RawURLEncoding.EncodeToString(cb)
+	hash := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hash[:])
+	if err != nil {
+		return "", fmt.Errorf("unable to sign jwt assertion. %s", err)
+	}
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
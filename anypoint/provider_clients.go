@@ -34,8 +34,10 @@ import (
 )
 
 type ProviderConfOutput struct {
-	access_token            string
+	tokensource             *tokenSource
 	server_index            int
+	defaults                providerDefaults
+	envnamecache            *envNameCache
 	vpcclient               *vpc.APIClient
 	vpnclient               *vpn.APIClient
 	orgclient               *org.APIClient
@@ -43,13 +45,19 @@ type ProviderConfOutput struct {
 	rolegroupclient         *rolegroup.APIClient
 	userclient              *user.APIClient
 	envclient               *env.APIClient
+	envsettingsclient       *RestAPIClient
+	hybridclient            *RestAPIClient
+	appmanagerrestclient    *RestAPIClient
+	exchangeclient          *RestAPIClient
 	userrgpclient           *user_rolegroups.APIClient
 	teamclient              *team.APIClient
 	teammembersclient       *team_members.APIClient
 	teamrolesclient         *team_roles.APIClient
 	teamgroupmappingsclient *team_group_mappings.APIClient
 	dlbclient               *dlb.APIClient
+	dlbcertificateclient    *RestAPIClient
 	idpclient               *idp.APIClient
+	accountsclient          *RestAPIClient
 	connectedappclient      *connected_app.APIClient
 	amqclient               *amq.APIClient
 	ameclient               *ame.APIClient
@@ -57,6 +65,7 @@ type ProviderConfOutput struct {
 	apimclient              *apim.APIClient
 	apimpolicyclient        *apim_policy.APIClient
 	apimupstreamclient      *apim_upstream.APIClient
+	apimoutboundclient      *RestAPIClient
 	flexgatewayclient       *flexgateway.APIClient
 	secretgroupclient       *secretgroup.APIClient
 	sgkeystoreclient        *secretgroup_keystore.APIClient
@@ -64,11 +73,14 @@ type ProviderConfOutput struct {
 	sgcertificateclient     *secretgroup_certificate.APIClient
 	sgtlscontextclient      *secretgroup_tlscontext.APIClient
 	sgcrldistribcfgsclient  *secretgroup_crl_distributor_configs.APIClient
+	secretsmanagerclient    *RestAPIClient
 	rtfclient               *rtf.APIClient
 	appmanagerclient        *application_manager_v2.APIClient
 }
 
-func newProviderConfOutput(access_token string, server_index int) ProviderConfOutput {
+func newProviderConfOutput(tokensource *tokenSource, server_index int) ProviderConfOutput {
+	//requests rejected because of an expired token are retried with a renewed one
+	httpclient := newTokenRefreshHttpClient(tokensource)
 	//preparing clients
 	vpccfg := vpc.NewConfiguration()
 	vpncfg := vpn.NewConfiguration()
@@ -100,6 +112,36 @@ func newProviderConfOutput(access_token string, server_index int) ProviderConfOu
 	sgcrldistribcfgs_cfg := secretgroup_crl_distributor_configs.NewConfiguration()
 	rtf_cfg := rtf.NewConfiguration()
 	appmanager_cfg := application_manager_v2.NewConfiguration()
+	vpccfg.HTTPClient = httpclient
+	vpncfg.HTTPClient = httpclient
+	orgcfg.HTTPClient = httpclient
+	rolecfg.HTTPClient = httpclient
+	rolegroupcfg.HTTPClient = httpclient
+	usercfg.HTTPClient = httpclient
+	envcfg.HTTPClient = httpclient
+	userrolegroupscfg.HTTPClient = httpclient
+	teamcfg.HTTPClient = httpclient
+	teammemberscfg.HTTPClient = httpclient
+	teamrolescfg.HTTPClient = httpclient
+	teamgroupmappingscfg.HTTPClient = httpclient
+	dlbcfg.HTTPClient = httpclient
+	idpcfg.HTTPClient = httpclient
+	connectedappcfg.HTTPClient = httpclient
+	amqcfg.HTTPClient = httpclient
+	amecfg.HTTPClient = httpclient
+	amebindingcfg.HTTPClient = httpclient
+	apimcfg.HTTPClient = httpclient
+	apimpolicycfg.HTTPClient = httpclient
+	apimupstreamcfg.HTTPClient = httpclient
+	flexgatewaycfg.HTTPClient = httpclient
+	secretgroupcfg.HTTPClient = httpclient
+	sgkeystorecfg.HTTPClient = httpclient
+	sgtruststorecfg.HTTPClient = httpclient
+	sgcertificatecfg.HTTPClient = httpclient
+	sgtlscontextcfg.HTTPClient = httpclient
+	sgcrldistribcfgs_cfg.HTTPClient = httpclient
+	rtf_cfg.HTTPClient = httpclient
+	appmanager_cfg.HTTPClient = httpclient
 
 	vpcclient := vpc.NewAPIClient(vpccfg)
 	vpnclient := vpn.NewAPIClient(vpncfg)
@@ -108,13 +150,19 @@ func newProviderConfOutput(access_token string, server_index int) ProviderConfOu
 	rolegroupclient := rolegroup.NewAPIClient(rolegroupcfg)
 	userclient := user.NewAPIClient(usercfg)
 	envclient := env.NewAPIClient(envcfg)
+	envsettingsclient := NewRestAPIClient("/monitoring/api/v2", httpclient)
+	hybridclient := NewRestAPIClient("/hybrid/api/v1", httpclient)
+	appmanagerrestclient := NewRestAPIClient("/amc/application-manager/api/v2", httpclient)
+	exchangeclient := NewRestAPIClient("/exchange/api/v2", httpclient)
 	userrgpclient := user_rolegroups.NewAPIClient(userrolegroupscfg)
 	teamclient := team.NewAPIClient(teamcfg)
 	teammembersclient := team_members.NewAPIClient(teammemberscfg)
 	teamrolesclient := team_roles.NewAPIClient(teamrolescfg)
 	teamgroupmappingsclient := team_group_mappings.NewAPIClient(teamgroupmappingscfg)
 	dlbclient := dlb.NewAPIClient(dlbcfg)
+	dlbcertificateclient := NewRestAPIClient("/cloudhub/api", httpclient)
 	idpclient := idp.NewAPIClient(idpcfg)
+	accountsclient := NewRestAPIClient("/accounts/api", httpclient)
 	connectedappclient := connected_app.NewAPIClient(connectedappcfg)
 	amqclient := amq.NewAPIClient(amqcfg)
 	ameclient := ame.NewAPIClient(amecfg)
@@ -122,6 +170,7 @@ func newProviderConfOutput(access_token string, server_index int) ProviderConfOu
 	apimclient := apim.NewAPIClient(apimcfg)
 	apimpolicyclient := apim_policy.NewAPIClient(apimpolicycfg)
 	apimupstreamclient := apim_upstream.NewAPIClient(apimupstreamcfg)
+	apimoutboundclient := NewRestAPIClient("/apimanager/api/v1", httpclient)
 	flexgatewayclient := flexgateway.NewAPIClient(flexgatewaycfg)
 	secretgroupclient := secretgroup.NewAPIClient(secretgroupcfg)
 	sgkeystoreclient := secretgroup_keystore.NewAPIClient(sgkeystorecfg)
@@ -129,12 +178,14 @@ func newProviderConfOutput(access_token string, server_index int) ProviderConfOu
 	sgcertificateclient := secretgroup_certificate.NewAPIClient(sgcertificatecfg)
 	sgtlscontextclient := secretgroup_tlscontext.NewAPIClient(sgtlscontextcfg)
 	sgcrldistribcfgsclient := secretgroup_crl_distributor_configs.NewAPIClient(sgcrldistribcfgs_cfg)
+	secretsmanagerclient := NewRestAPIClient("/secrets-manager/api/v1", httpclient)
 	rtfclient := rtf.NewAPIClient(rtf_cfg)
 	appmanagerclient := application_manager_v2.NewAPIClient(appmanager_cfg)
 
 	return ProviderConfOutput{
-		access_token:            access_token,
+		tokensource:             tokensource,
 		server_index:            server_index,
+		envnamecache:            newEnvNameCache(),
 		vpcclient:               vpcclient,
 		vpnclient:               vpnclient,
 		orgclient:               orgclient,
@@ -142,13 +193,19 @@ func newProviderConfOutput(access_token string, server_index int) ProviderConfOu
 		rolegroupclient:         rolegroupclient,
 		userclient:              userclient,
 		envclient:               envclient,
+		envsettingsclient:       envsettingsclient,
+		hybridclient:            hybridclient,
+		appmanagerrestclient:    appmanagerrestclient,
+		exchangeclient:          exchangeclient,
 		userrgpclient:           userrgpclient,
 		teamclient:              teamclient,
 		teammembersclient:       teammembersclient,
 		teamrolesclient:         teamrolesclient,
 		teamgroupmappingsclient: teamgroupmappingsclient,
 		dlbclient:               dlbclient,
+		dlbcertificateclient:    dlbcertificateclient,
 		idpclient:               idpclient,
+		accountsclient:          accountsclient,
 		connectedappclient:      connectedappclient,
 		amqclient:               amqclient,
 		ameclient:               ameclient,
@@ -156,6 +213,7 @@ func newProviderConfOutput(access_token string, server_index int) ProviderConfOu
 		apimclient:              apimclient,
 		apimpolicyclient:        apimpolicyclient,
 		apimupstreamclient:      apimupstreamclient,
+		apimoutboundclient:      apimoutboundclient,
 		flexgatewayclient:       flexgatewayclient,
 		secretgroupclient:       secretgroupclient,
 		sgkeystoreclient:        sgkeystoreclient,
@@ -163,6 +221,7 @@ func newProviderConfOutput(access_token string, server_index int) ProviderConfOu
 		sgcertificateclient:     sgcertificateclient,
 		sgtlscontextclient:      sgtlscontextclient,
 		sgcrldistribcfgsclient:  sgcrldistribcfgsclient,
+		secretsmanagerclient:    secretsmanagerclient,
 		rtfclient:               rtfclient,
 		appmanagerclient:        appmanagerclient,
 	}
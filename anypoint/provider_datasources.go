@@ -8,10 +8,12 @@ var DATASOURCES_MAP = map[string]*schema.Resource{
 	"anypoint_vpn":                                   dataSourceVPN(),
 	"anypoint_bg":                                    dataSourceBG(),
 	"anypoint_roles":                                 dataSourceRoles(),
+	"anypoint_permission_catalog":                    dataSourcePermissionCatalog(),
 	"anypoint_rolegroup":                             dataSourceRoleGroup(),
 	"anypoint_rolegroups":                            dataSourceRoleGroups(),
 	"anypoint_users":                                 dataSourceUsers(),
 	"anypoint_user":                                  dataSourceUser(),
+	"anypoint_user_invitations":                      dataSourceUserInvitations(),
 	"anypoint_env":                                   dataSourceENV(),
 	"anypoint_user_rolegroup":                        dataSourceUserRolegroup(),
 	"anypoint_user_rolegroups":                       dataSourceUserRolegroups(),
@@ -24,14 +26,18 @@ var DATASOURCES_MAP = map[string]*schema.Resource{
 	"anypoint_dlbs":                                  dataSourceDLBs(),
 	"anypoint_idp":                                   dataSourceIDP(),
 	"anypoint_idps":                                  dataSourceIDPs(),
+	"anypoint_client_provider":                       dataSourceClientProvider(),
 	"anypoint_connected_app":                         dataSourceConnectedApp(),
 	"anypoint_connected_apps":                        dataSourceConnectedApps(),
+	"anypoint_connected_app_scopes":                  dataSourceConnectedAppScopes(),
 	"anypoint_amq":                                   dataSourceAMQ(),
 	"anypoint_ame":                                   dataSourceAME(),
 	"anypoint_apim":                                  dataSourceApim(),
 	"anypoint_apim_instance":                         dataSourceApimInstance(),
 	"anypoint_apim_instance_policy":                  dataSourceApimInstancePolicy(),
 	"anypoint_apim_instance_policies":                dataSourceApimInstancePolicies(),
+	"anypoint_apim_outbound_policy":                  dataSourceApimOutboundPolicy(),
+	"anypoint_apim_outbound_policies":                dataSourceApimOutboundPolicies(),
 	"anypoint_apim_instance_upstreams":               dataSourceApimInstanceUpstreams(),
 	"anypoint_flexgateway_target":                    dataSourceFlexGatewayTarget(),
 	"anypoint_flexgateway_targets":                   dataSourceFlexGatewayTargets(),
@@ -50,6 +56,8 @@ var DATASOURCES_MAP = map[string]*schema.Resource{
 	"anypoint_secretgroup_tlscontext_mule":           dataSourceSecretGroupTlsContextMule(),
 	"anypoint_secretgroup_crldistrib_cfgs_list":      dataSourceSecretGroupCrlDistribCfgsList(),
 	"anypoint_secretgroup_crldistrib_cfgs":           dataSourceSecretGroupCrlDistribCfgs(),
+	"anypoint_secretgroup_shared_secret":             dataSourceSecretGroupSharedSecret(),
+	"anypoint_certificate_expiry":                    dataSourceCertificateExpiry(),
 	"anypoint_exchange_policy_templates":             dataSourceExchangePolicyTemplates(),
 	"anypoint_exchange_policy_template":              dataSourceExchangePolicyTemplate(),
 	"anypoint_fabrics_list":                          dataSourceFabricsCollection(),
@@ -59,4 +67,7 @@ var DATASOURCES_MAP = map[string]*schema.Resource{
 	"anypoint_fabrics_health":                        dataSourceFabricsHealth(),
 	"anypoint_app_deployment_v2":                     dataSourceAppDeploymentV2(),
 	"anypoint_app_deployments_v2":                    dataSourceAppDeploymentsV2(),
+	"anypoint_app_deployment_v2_logs":                dataSourceAppDeploymentV2Logs(),
+	"anypoint_hybrid_server":                         dataSourceHybridServer(),
+	"anypoint_hybrid_servers":                        dataSourceHybridServers(),
 }
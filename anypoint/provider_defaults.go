@@ -0,0 +1,157 @@
+package anypoint
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+/*
+ * Provider level defaults.
+ * The resources omitting org_id or env_id inherit the provider's default_org_id and default_env_id (or default_env_name).
+ * Environment names are resolved per organization and cached for the lifetime of the provider instance.
+ */
+
+type providerDefaults struct {
+	org_id              string
+	env_id              string
+	env_name            string
+	protected_env_types []string
+}
+
+type envName struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type,omitempty"`
+}
+
+type envNamesResponse struct {
+	Data  []envName `json:"data"`
+	Total int       `json:"total,omitempty"`
+}
+
+// loads the defaults set in the provider configuration
+func newProviderDefaults(d *schema.ResourceData) providerDefaults {
+	return providerDefaults{
+		org_id:              d.Get("default_org_id").(string),
+		env_id:              d.Get("default_env_id").(string),
+		env_name:            d.Get("default_env_name").(string),
+		protected_env_types: ListInterface2ListStrings(d.Get("protected_environment_types").([]interface{})),
+	}
+}
+
+// the environments by organization
+type envNameCache struct {
+	mu   sync.Mutex
+	envs map[string][]envName
+}
+
+func newEnvNameCache() *envNameCache {
+	return &envNameCache{
+		envs: make(map[string][]envName),
+	}
+}
+
+// fills org_id and env_id with the provider defaults when they are not set in the configuration.
+// to be used in the CustomizeDiff of the resources having org_id and env_id attributes.
+func customizeDiffProviderDefaults(ctx context.Context, rd *schema.ResourceDiff, m interface{}) error {
+	pco := m.(ProviderConfOutput)
+	raw := rd.GetRawConfig()
+	if raw.IsNull() || !raw.IsKnown() {
+		return nil
+	}
+	if raw.Type().HasAttribute("org_id") && raw.GetAttr("org_id").IsNull() {
+		if pco.defaults.org_id == "" {
+			return fmt.Errorf("\"org_id\": required field is not set and the provider has no default_org_id")
+		}
+		if rd.Get("org_id").(string) != pco.defaults.org_id {
+			if err := rd.SetNew("org_id", pco.defaults.org_id); err != nil {
+				return err
+			}
+		}
+	}
+	if !raw.Type().HasAttribute("env_id") || !raw.GetAttr("env_id").IsNull() {
+		return nil
+	}
+	envid := pco.defaults.env_id
+	if envid == "" && pco.defaults.env_name != "" {
+		// the environment name can only be resolved once the organization is known
+		if !rd.NewValueKnown("org_id") {
+			return rd.SetNewComputed("env_id")
+		}
+		orgid := rd.Get("org_id").(string)
+		id, err := resolveEnvironmentId(ctx, &pco, orgid, pco.defaults.env_name)
+		if err != nil {
+			return err
+		}
+		envid = id
+	}
+	if envid == "" {
+		return fmt.Errorf("\"env_id\": required field is not set and the provider has neither default_env_id nor default_env_name")
+	}
+	if rd.Get("env_id").(string) != envid {
+		return rd.SetNew("env_id", envid)
+	}
+	return nil
+}
+
+// returns the id of the environment with the given name in the given org
+func resolveEnvironmentId(ctx context.Context, pco *ProviderConfOutput, orgid string, name string) (string, error) {
+	envs, err := getOrgEnvironments(ctx, pco, orgid)
+	if err != nil {
+		return "", err
+	}
+	for _, env := range envs {
+		if env.Name == name {
+			return env.Id, nil
+		}
+	}
+	return "", fmt.Errorf("environment %s not found in org %s", name, orgid)
+}
+
+// returns the type of the environment with the given id in the given org (production, sandbox or design)
+func getEnvironmentType(ctx context.Context, pco *ProviderConfOutput, orgid string, envid string) (string, error) {
+	envs, err := getOrgEnvironments(ctx, pco, orgid)
+	if err != nil {
+		return "", err
+	}
+	for _, env := range envs {
+		if env.Id == envid {
+			return env.Type, nil
+		}
+	}
+	return "", fmt.Errorf("environment %s not found in org %s", envid, orgid)
+}
+
+// returns true if the given environment type is protected by the provider's protected_environment_types
+func isProtectedEnvironmentType(pco *ProviderConfOutput, envtype string) bool {
+	return envtype != "" && StringInSlice(pco.defaults.protected_env_types, envtype, true)
+}
+
+// returns the environments of the given org.
+// the environments of an org are loaded once per provider instance.
+func getOrgEnvironments(ctx context.Context, pco *ProviderConfOutput, orgid string) ([]envName, error) {
+	cache := pco.envnamecache
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if envs, ok := cache.envs[orgid]; ok {
+		return envs, nil
+	}
+	authctx := getAccountsAuthCtx(ctx, pco)
+	var res envNamesResponse
+	httpr, err := pco.accountsclient.Execute(authctx, http.MethodGet, getEnvNamesPath(orgid), nil, &res)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get environments of org %s. %s", orgid, getHttpErrorDetails(httpr, err))
+	}
+	httpr.Body.Close()
+	cache.envs[orgid] = res.Data
+	return res.Data, nil
+}
+
+func getEnvNamesPath(orgid string) string {
+	return fmt.Sprintf("/organizations/%s/environments", url.PathEscape(orgid))
+}
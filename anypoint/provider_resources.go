@@ -6,18 +6,22 @@ var RESOURCES_MAP = map[string]*schema.Resource{
 	"anypoint_vpc":                                   resourceVPC(),
 	"anypoint_vpn":                                   resourceVPN(),
 	"anypoint_bg":                                    resourceBG(),
+	"anypoint_org_security_settings":                 resourceOrgSecuritySettings(),
 	"anypoint_rolegroup_roles":                       resourceRoleGroupRoles(),
 	"anypoint_rolegroup":                             resourceRoleGroup(),
 	"anypoint_env":                                   resourceENV(),
 	"anypoint_user":                                  resourceUser(),
+	"anypoint_user_invitation":                       resourceUserInvitation(),
 	"anypoint_user_rolegroup":                        resourceUserRolegroup(),
 	"anypoint_team":                                  resourceTeam(),
 	"anypoint_team_roles":                            resourceTeamRoles(),
 	"anypoint_team_member":                           resourceTeamMember(),
+	"anypoint_team_members_exclusive":                resourceTeamMembersExclusive(),
 	"anypoint_team_group_mappings":                   resourceTeamGroupMappings(),
 	"anypoint_dlb":                                   resourceDLB(),
 	"anypoint_idp_oidc":                              resourceOIDC(),
 	"anypoint_idp_saml":                              resourceSAML(),
+	"anypoint_client_provider":                       resourceClientProvider(),
 	"anypoint_connected_app":                         resourceConnectedApp(),
 	"anypoint_amq":                                   resourceAMQ(),
 	"anypoint_ame":                                   resourceAME(),
@@ -29,7 +33,20 @@ var RESOURCES_MAP = map[string]*schema.Resource{
 	"anypoint_apim_policy_rate_limiting":             resourceApimInstancePolicyRateLimiting(),
 	"anypoint_apim_policy_basic_auth":                resourceApimInstancePolicyBasicAuth(),
 	"anypoint_apim_policy_message_logging":           resourceApimInstancePolicyMessageLogging(),
+	"anypoint_apim_policy_spike_control":             resourceApimInstancePolicySpikeControl(),
+	"anypoint_apim_policy_rate_limiting_sla":         resourceApimInstancePolicyRateLimitingSla(),
+	"anypoint_apim_policy_ip_allowlist":              resourceApimInstancePolicyIpAllowlist(),
+	"anypoint_apim_policy_ip_blocklist":              resourceApimInstancePolicyIpBlocklist(),
+	"anypoint_apim_policy_header_injection":          resourceApimInstancePolicyHeaderInjection(),
+	"anypoint_apim_policy_header_removal":            resourceApimInstancePolicyHeaderRemoval(),
+	"anypoint_apim_policy_cors":                      resourceApimInstancePolicyCors(),
+	"anypoint_apim_policy_oauth2_introspection":      resourceApimInstancePolicyOAuth2Introspection(),
+	"anypoint_apim_policy_oidc_token_enforcement":    resourceApimInstancePolicyOidcTokenEnforcement(),
+	"anypoint_apim_policy_json_threat_protection":    resourceApimInstancePolicyJsonThreatProtection(),
+	"anypoint_apim_policy_xml_threat_protection":     resourceApimInstancePolicyXmlThreatProtection(),
+	"anypoint_apim_policy_http_caching":              resourceApimInstancePolicyHttpCaching(),
 	"anypoint_apim_policy_custom":                    resourceApimInstancePolicyCustom(),
+	"anypoint_apim_outbound_policy":                  resourceApimOutboundPolicy(),
 	"anypoint_secretgroup":                           resourceSecretGroup(),
 	"anypoint_secretgroup_keystore":                  resourceSecretGroupKeystore(),
 	"anypoint_secretgroup_truststore":                resourceSecretGroupTruststore(),
@@ -38,8 +55,15 @@ var RESOURCES_MAP = map[string]*schema.Resource{
 	"anypoint_secretgroup_tlscontext_mule":           resourceSecretGroupTlsContextMule(),
 	"anypoint_secretgroup_tlscontext_securityfabric": resourceSecretGroupTlsContextSF(),
 	"anypoint_secretgroup_crldistrib_cfgs":           resourceSecretGroupCrlDistribCfgs(),
+	"anypoint_secretgroup_shared_secret":             resourceSecretGroupSharedSecret(),
+	"anypoint_secretgroup_finalize":                  resourceSecretGroupFinalize(),
+	"anypoint_secretgroup_copy":                      resourceSecretGroupCopy(),
 	"anypoint_fabrics":                               resourceFabrics(),
 	"anypoint_fabrics_associations":                  resourceFabricsAssociations(),
 	"anypoint_cloudhub2_shared_space_deployment":     resourceCloudhub2SharedSpaceDeployment(),
 	"anypoint_rtf_deployment":                        resourceRTFDeployment(),
+	"anypoint_hybrid_server_registration_token":      resourceHybridServerRegistrationToken(),
+	"anypoint_hybrid_server_group":                   resourceHybridServerGroup(),
+	"anypoint_hybrid_cluster":                        resourceHybridCluster(),
+	"anypoint_hybrid_deployment":                     resourceHybridDeployment(),
 }
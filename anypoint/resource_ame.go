@@ -17,6 +17,7 @@ func resourceAME() *schema.Resource {
 		ReadContext:   resourceAMERead,
 		UpdateContext: resourceAMEUpdate,
 		DeleteContext: resourceAMEDelete,
+		CustomizeDiff: customizeDiffProviderDefaults,
 		Description: `
 		Creates an ` + "`" + `Anypoint MQ Exchange` + "`" + ` in your ` + "`" + `region` + "`" + `.
 		`,
@@ -40,15 +41,17 @@ func resourceAME() *schema.Resource {
 			},
 			"org_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The organization id where the Anypoint MQ Exchange is defined.",
+				Description: "The organization id where the Anypoint MQ Exchange is defined. Defaults to the provider's default_org_id.",
 			},
 			"env_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The environment id where the Anypoint MQ Exchange is defined.",
+				Description: "The environment id where the Anypoint MQ Exchange is defined. Defaults to the provider's default_env_id or default_env_name.",
 			},
 			"region_id": {
 				Type:        schema.TypeString,
@@ -267,6 +270,6 @@ func getAMEPatchWatchAttributes() []string {
  * Returns authentication context (includes authorization header)
  */
 func getAMEAuthCtx(ctx context.Context, pco *ProviderConfOutput) context.Context {
-	tmp := context.WithValue(ctx, ame.ContextAccessToken, pco.access_token)
+	tmp := context.WithValue(ctx, ame.ContextAccessToken, pco.tokensource.Token(ctx))
 	return context.WithValue(tmp, ame.ContextServerIndex, pco.server_index)
 }
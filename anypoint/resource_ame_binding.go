@@ -17,6 +17,7 @@ func resourceAMEBinding() *schema.Resource {
 		ReadContext:   resourceAMEBindingRead,
 		UpdateContext: resourceAMEBindingUpdate,
 		DeleteContext: resourceAMEBindingDelete,
+		CustomizeDiff: customizeDiffProviderDefaults,
 		Description: `
 		Creates an ` + "`" + `Anypoint MQ Exchange Binding` + "`" + ` in your ` + "`" + `region` + "`" + `.
 		`,
@@ -46,15 +47,17 @@ func resourceAMEBinding() *schema.Resource {
 			},
 			"org_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The organization id where the Anypoint MQ Exchange is defined.",
+				Description: "The organization id where the Anypoint MQ Exchange is defined. Defaults to the provider's default_org_id.",
 			},
 			"env_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The environment id where the Anypoint MQ Exchange is defined.",
+				Description: "The environment id where the Anypoint MQ Exchange is defined. Defaults to the provider's default_env_id or default_env_name.",
 			},
 			"region_id": {
 				Type:        schema.TypeString,
@@ -642,6 +645,6 @@ func getAMEBindingRulesWatchAttributes() []string {
  * Returns authentication context (includes authorization header)
  */
 func getAMEBindingAuthCtx(ctx context.Context, pco *ProviderConfOutput) context.Context {
-	tmp := context.WithValue(ctx, ame_binding.ContextAccessToken, pco.access_token)
+	tmp := context.WithValue(ctx, ame_binding.ContextAccessToken, pco.tokensource.Token(ctx))
 	return context.WithValue(tmp, ame_binding.ContextServerIndex, pco.server_index)
 }
@@ -17,6 +17,7 @@ func resourceAMQ() *schema.Resource {
 		ReadContext:   resourceAMQRead,
 		UpdateContext: resourceAMQUpdate,
 		DeleteContext: resourceAMQDelete,
+		CustomizeDiff: customizeDiffProviderDefaults,
 		Description: `
 		Creates an ` + "`" + `Anypoint MQ` + "`" + ` in your ` + "`" + `region` + "`" + `.
 		`,
@@ -40,15 +41,17 @@ func resourceAMQ() *schema.Resource {
 			},
 			"org_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The organization id where the Anypoint MQ is defined.",
+				Description: "The organization id where the Anypoint MQ is defined. Defaults to the provider's default_org_id.",
 			},
 			"env_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The environment id where the Anypoint MQ is defined.",
+				Description: "The environment id where the Anypoint MQ is defined. Defaults to the provider's default_env_id or default_env_name.",
 			},
 			"region_id": {
 				Type:        schema.TypeString,
@@ -336,6 +339,6 @@ func getAMQPatchWatchAttributes() []string {
  * Returns authentication context (includes authorization header)
  */
 func getAMQAuthCtx(ctx context.Context, pco *ProviderConfOutput) context.Context {
-	tmp := context.WithValue(ctx, amq.ContextAccessToken, pco.access_token)
+	tmp := context.WithValue(ctx, amq.ContextAccessToken, pco.tokensource.Token(ctx))
 	return context.WithValue(tmp, amq.ContextServerIndex, pco.server_index)
 }
@@ -55,15 +55,17 @@ func resourceApimFlexGateway() *schema.Resource {
 			},
 			"org_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The organization id where the flex gateway instance is defined.",
+				Description: "The organization id where the flex gateway instance is defined. Defaults to the provider's default_org_id.",
 			},
 			"env_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The environment id where the flex gateway instance is defined.",
+				Description: "The environment id where the flex gateway instance is defined. Defaults to the provider's default_env_id or default_env_name.",
 			},
 			"instance_label": {
 				Type:        schema.TypeString,
@@ -478,6 +480,9 @@ func resourceApimFlexGateway() *schema.Resource {
 			},
 		},
 		CustomizeDiff: func(ctx context.Context, rd *schema.ResourceDiff, i interface{}) error {
+			if err := customizeDiffProviderDefaults(ctx, rd, i); err != nil {
+				return err
+			}
 			return validateRoutingUpstreams(rd)
 		},
 		Importer: &schema.ResourceImporter{
@@ -1348,6 +1353,6 @@ func isUpstreamTlsContextEqual(a *schema.Set, b *schema.Set) bool {
  * Returns authentication context (includes authorization header)
  */
 func getFlexGatewayAuthCtx(ctx context.Context, pco *ProviderConfOutput) context.Context {
-	tmp := context.WithValue(ctx, flexgateway.ContextAccessToken, pco.access_token)
+	tmp := context.WithValue(ctx, flexgateway.ContextAccessToken, pco.tokensource.Token(ctx))
 	return context.WithValue(tmp, flexgateway.ContextServerIndex, pco.server_index)
 }
@@ -23,6 +23,7 @@ func resourceApimMule4() *schema.Resource {
 		ReadContext:   resourceApimMule4Read,
 		UpdateContext: resourceApimMule4Update,
 		DeleteContext: resourceApimMule4Delete,
+		CustomizeDiff: customizeDiffProviderDefaults,
 		Description: `
 		Create and manage an API Manager Instance of type Mule4.
 		`,
@@ -50,15 +51,17 @@ func resourceApimMule4() *schema.Resource {
 			},
 			"org_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The organization id where the api manager instance is defined.",
+				Description: "The organization id where the api manager instance is defined. Defaults to the provider's default_org_id.",
 			},
 			"env_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The environment id where the api manager instance is defined.",
+				Description: "The environment id where the api manager instance is defined. Defaults to the provider's default_env_id or default_env_name.",
 			},
 			"instance_label": {
 				Type:        schema.TypeString,
@@ -0,0 +1,440 @@
+package anypoint
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/mulesoft-anypoint/anypoint-client-go/apim_policy"
+)
+
+// an outbound policy is an api manager policy bound to one or more upstreams of a flex gateway api instance
+type apimOutboundPolicy struct {
+	apim_policy.ApimPolicy
+	UpstreamIds []string `json:"upstreamIds,omitempty"`
+}
+
+func resourceApimOutboundPolicy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceApimOutboundPolicyCreate,
+		ReadContext:   resourceApimOutboundPolicyRead,
+		UpdateContext: resourceApimOutboundPolicyUpdate,
+		DeleteContext: resourceApimOutboundPolicyDelete,
+		CustomizeDiff: customizeDiffProviderDefaults,
+		Description: `
+		Create and manage an outbound API Policy of any type for a Flex Gateway API instance.
+		Outbound policies are applied to the requests sent to the api's upstreams (i.e. upstream TLS, credentials injection, headers manipulation).
+		`,
+		Schema: map[string]*schema.Schema{
+			"last_updated": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The last time this resource has been updated locally.",
+			},
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The policy's unique id",
+			},
+			"apim_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The api manager instance id where the api instance is defined.",
+			},
+			"org_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The organization id where the api instance is defined. Defaults to the provider's default_org_id.",
+			},
+			"env_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The environment id where api instance is defined. Defaults to the provider's default_env_id or default_env_name.",
+			},
+			"upstream_ids": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Description: "The list of upstream ids of the api instance the policy is applied to.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"audit": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "The instance's auditing data",
+			},
+			"master_organization_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The organization id where the api instance is defined.",
+			},
+			"configuration_data": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "The policy configuration data in json format",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsJSON),
+			},
+			"policy_template_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The policy template id",
+			},
+			"order": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The policy order.",
+			},
+			"disabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the policy is disabled.",
+			},
+			"asset_group_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The policy template group id in anypoint exchange. Don't change unless mulesoft has renamed the policy group id.",
+			},
+			"asset_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The policy template id in anypoint exchange. Don't change unless mulesoft has renamed the policy asset id.",
+			},
+			"asset_version": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "the policy template version in anypoint exchange.",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceApimOutboundPolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid := d.Get("org_id").(string)
+	envid := d.Get("env_id").(string)
+	apimid := d.Get("apim_id").(string)
+	authctx := getApimOutboundPolicyAuthCtx(ctx, &pco)
+	//prepare body
+	body, err := newApimOutboundPolicyBody(d)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to parse outbound policy configuration for api " + apimid,
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	//perform request
+	var res apimOutboundPolicy
+	httpr, err := pco.apimoutboundclient.Execute(authctx, http.MethodPost, getApimOutboundPoliciesPath(orgid, envid, apimid), body, &res)
+	if err != nil {
+		var details string
+		if httpr != nil && httpr.StatusCode >= 400 {
+			defer httpr.Body.Close()
+			b, _ := io.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to create outbound policy for api " + apimid,
+			Detail:   details,
+		})
+		return diags
+	}
+	defer httpr.Body.Close()
+	id := res.GetId()
+	d.SetId(strconv.Itoa(int(id)))
+	diags = append(diags, resourceApimOutboundPolicyRead(ctx, d, m)...)
+	//in case disabled
+	disabled := d.Get("disabled").(bool)
+	if disabled {
+		diags = append(diags, toggleApimOutboundPolicy(ctx, d, m, false)...)
+		diags = append(diags, resourceApimOutboundPolicyRead(ctx, d, m)...)
+	}
+
+	return diags
+}
+
+func resourceApimOutboundPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid := d.Get("org_id").(string)
+	envid := d.Get("env_id").(string)
+	apimid := d.Get("apim_id").(string)
+	id := d.Get("id").(string)
+	if isComposedResourceId(id) {
+		orgid, envid, apimid, id = decomposeApimOutboundPolicyId(d)
+	}
+	authctx := getApimOutboundPolicyAuthCtx(ctx, &pco)
+	//perform request
+	var res apimOutboundPolicy
+	httpr, err := pco.apimoutboundclient.Execute(authctx, http.MethodGet, getApimOutboundPolicyPath(orgid, envid, apimid, id), nil, &res)
+	if err != nil {
+		var details string
+		if httpr != nil && httpr.StatusCode >= 400 {
+			defer httpr.Body.Close()
+			b, _ := io.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to read outbound policy " + id + " for api " + apimid,
+			Detail:   details,
+		})
+		return diags
+	}
+	defer httpr.Body.Close()
+	// process data
+	data := flattenApimOutboundPolicy(&res)
+	if cfg, err := flattenApimPolicyCustomCfg(d, &res.ApimPolicy); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to parse configuration data of outbound policy " + id + " for api " + apimid,
+			Detail:   err.Error(),
+		})
+		return diags
+	} else {
+		data["configuration_data"] = cfg
+	}
+	if err := setApimOutboundPolicyAttributesToResourceData(d, data); err != nil {
+		diags := append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to set api outbound policy " + id + " details attributes",
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	d.SetId(id)
+	d.Set("apim_id", apimid)
+	d.Set("env_id", envid)
+	d.Set("org_id", orgid)
+	return diags
+}
+
+func resourceApimOutboundPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	//detect change
+	if d.HasChanges("configuration_data", "upstream_ids") {
+		pco := m.(ProviderConfOutput)
+		orgid := d.Get("org_id").(string)
+		envid := d.Get("env_id").(string)
+		apimid := d.Get("apim_id").(string)
+		id := d.Get("id").(string)
+		authctx := getApimOutboundPolicyAuthCtx(ctx, &pco)
+		//prepare body
+		body, err := newApimOutboundPolicyPatchBody(d)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to parse outbound policy configuration for api " + apimid,
+				Detail:   err.Error(),
+			})
+			return diags
+		}
+		//perform request
+		httpr, err := pco.apimoutboundclient.Execute(authctx, http.MethodPatch, getApimOutboundPolicyPath(orgid, envid, apimid, id), body, nil)
+		if err != nil {
+			var details string
+			if httpr != nil && httpr.StatusCode >= 400 {
+				defer httpr.Body.Close()
+				b, _ := io.ReadAll(httpr.Body)
+				details = string(b)
+			} else {
+				details = err.Error()
+			}
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to update outbound policy for api " + apimid,
+				Detail:   details,
+			})
+			return diags
+		}
+		defer httpr.Body.Close()
+		diags = append(diags, resourceApimOutboundPolicyRead(ctx, d, m)...)
+	}
+	if d.HasChange("disabled") {
+		disabled := d.Get("disabled").(bool)
+		diags = append(diags, toggleApimOutboundPolicy(ctx, d, m, !disabled)...)
+		diags = append(diags, resourceApimOutboundPolicyRead(ctx, d, m)...)
+	}
+
+	return diags
+}
+
+func resourceApimOutboundPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid := d.Get("org_id").(string)
+	envid := d.Get("env_id").(string)
+	apimid := d.Get("apim_id").(string)
+	id := d.Get("id").(string)
+	authctx := getApimOutboundPolicyAuthCtx(ctx, &pco)
+	httpr, err := pco.apimoutboundclient.Execute(authctx, http.MethodDelete, getApimOutboundPolicyPath(orgid, envid, apimid, id), nil, nil)
+	if err != nil {
+		var details string
+		if httpr != nil && httpr.StatusCode >= 400 {
+			defer httpr.Body.Close()
+			b, _ := io.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to delete outbound policy " + id + " for api " + apimid,
+			Detail:   details,
+		})
+		return diags
+	}
+	defer httpr.Body.Close()
+	// d.SetId("") is automatically called assuming delete returns no errors, but
+	// it is added here for explicitness.
+	d.SetId("")
+	return diags
+}
+
+// enables or disables the outbound policy
+func toggleApimOutboundPolicy(ctx context.Context, d *schema.ResourceData, m interface{}, enable bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid := d.Get("org_id").(string)
+	envid := d.Get("env_id").(string)
+	apimid := d.Get("apim_id").(string)
+	id := d.Get("id").(string)
+	action := "disable"
+	if enable {
+		action = "enable"
+	}
+	authctx := getApimOutboundPolicyAuthCtx(ctx, &pco)
+	httpr, err := pco.apimoutboundclient.Execute(authctx, http.MethodPost, getApimOutboundPolicyPath(orgid, envid, apimid, id)+"/"+action, nil, nil)
+	if err != nil {
+		var details string
+		if httpr != nil && httpr.StatusCode >= 400 {
+			defer httpr.Body.Close()
+			b, _ := io.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to " + action + " outbound policy " + id + " for api " + apimid,
+			Detail:   details,
+		})
+		return diags
+	}
+	defer httpr.Body.Close()
+	return diags
+}
+
+func flattenApimOutboundPolicy(policy *apimOutboundPolicy) map[string]interface{} {
+	result := flattenApimInstancePolicy(&policy.ApimPolicy)
+	result["upstream_ids"] = policy.UpstreamIds
+	return result
+}
+
+func setApimOutboundPolicyAttributesToResourceData(d *schema.ResourceData, data map[string]interface{}) error {
+	attributes := getApimOutboundPolicyAttributes()
+	if data != nil {
+		for _, attr := range attributes {
+			if val, ok := data[attr]; ok {
+				if err := d.Set(attr, val); err != nil {
+					return fmt.Errorf("unable to set api manager outbound policy attribute %s\n\tdetails: %s", attr, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func getApimOutboundPolicyAttributes() []string {
+	attributes := [...]string{
+		"audit", "master_organization_id", "configuration_data",
+		"order", "disabled", "policy_template_id", "asset_group_id",
+		"asset_id", "asset_version", "upstream_ids",
+	}
+	return attributes[:]
+}
+
+func newApimOutboundPolicyBody(d *schema.ResourceData) (map[string]interface{}, error) {
+	body, err := newApimOutboundPolicyPatchBody(d)
+	if err != nil {
+		return nil, err
+	}
+	if val, ok := d.GetOk("asset_group_id"); ok {
+		body["groupId"] = val
+	}
+	if val, ok := d.GetOk("asset_id"); ok {
+		body["assetId"] = val
+	}
+	if val, ok := d.GetOk("asset_version"); ok {
+		body["assetVersion"] = val
+	}
+	return body, nil
+}
+
+func newApimOutboundPolicyPatchBody(d *schema.ResourceData) (map[string]interface{}, error) {
+	body := make(map[string]interface{})
+	if val, ok := d.GetOk("configuration_data"); ok {
+		var cfg map[string]interface{}
+		err := json.Unmarshal([]byte(val.(string)), &cfg)
+		if err != nil {
+			return nil, fmt.Errorf("configuration_data expected to be a valid JSON Object. %s", err.Error())
+		}
+		body["configurationData"] = cfg
+	}
+	if val, ok := d.GetOk("upstream_ids"); ok {
+		body["upstreamIds"] = ListInterface2ListStrings(val.(*schema.Set).List())
+	}
+	return body, nil
+}
+
+func getApimOutboundPoliciesPath(orgid, envid, apimid string) string {
+	return fmt.Sprintf("/organizations/%s/environments/%s/apis/%s/policies/outbound-policies",
+		url.PathEscape(orgid), url.PathEscape(envid), url.PathEscape(apimid),
+	)
+}
+
+func getApimOutboundPolicyPath(orgid, envid, apimid, id string) string {
+	return getApimOutboundPoliciesPath(orgid, envid, apimid) + "/" + url.PathEscape(id)
+}
+
+func decomposeApimOutboundPolicyId(d *schema.ResourceData) (string, string, string, string) {
+	s := DecomposeResourceId(d.Id())
+	return s[0], s[1], s[2], s[3]
+}
+
+/*
+ * Returns authentication context (includes authorization header)
+ */
+func getApimOutboundPolicyAuthCtx(ctx context.Context, pco *ProviderConfOutput) context.Context {
+	tmp := context.WithValue(ctx, RestContextAccessToken, pco.tokensource.Token(ctx))
+	return context.WithValue(tmp, RestContextServerIndex, pco.server_index)
+}
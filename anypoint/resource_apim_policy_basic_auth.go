@@ -17,6 +17,7 @@ func resourceApimInstancePolicyBasicAuth() *schema.Resource {
 		ReadContext:   resourceApimInstancePolicyBasicAuthRead,
 		UpdateContext: resourceApimInstancePolicyBasicAuthUpdate,
 		DeleteContext: resourceApimInstancePolicyBasicAuthDelete,
+		CustomizeDiff: customizeDiffProviderDefaults,
 		Description: `
 		Create and manage an API Policy of type basic authentication.
 		`,
@@ -40,15 +41,17 @@ func resourceApimInstancePolicyBasicAuth() *schema.Resource {
 			},
 			"org_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The organization id where the api instance is defined.",
+				Description: "The organization id where the api instance is defined. Defaults to the provider's default_org_id.",
 			},
 			"env_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The environment id where api instance is defined.",
+				Description: "The environment id where api instance is defined. Defaults to the provider's default_env_id or default_env_name.",
 			},
 			"audit": {
 				Type:        schema.TypeMap,
@@ -44,15 +44,17 @@ func resourceApimInstancePolicyJwtValidation() *schema.Resource {
 			},
 			"org_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The organization id where the api instance is defined.",
+				Description: "The organization id where the api instance is defined. Defaults to the provider's default_org_id.",
 			},
 			"env_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The environment id where api instance is defined.",
+				Description: "The environment id where api instance is defined. Defaults to the provider's default_env_id or default_env_name.",
 			},
 			"audit": {
 				Type:        schema.TypeMap,
@@ -382,6 +384,9 @@ func resourceApimInstancePolicyJwtValidation() *schema.Resource {
 			},
 		},
 		CustomizeDiff: func(ctx context.Context, rd *schema.ResourceDiff, i interface{}) error {
+			if err := customizeDiffProviderDefaults(ctx, rd, i); err != nil {
+				return err
+			}
 			return validateJwtValidationCfg(rd)
 		},
 		Importer: &schema.ResourceImporter{
@@ -18,6 +18,7 @@ func resourceApimInstancePolicyMessageLogging() *schema.Resource {
 		ReadContext:   resourceApimInstancePolicyMessageLoggingRead,
 		UpdateContext: resourceApimInstancePolicyMessageLoggingUpdate,
 		DeleteContext: resourceApimInstancePolicyMessageLoggingDelete,
+		CustomizeDiff: customizeDiffProviderDefaults,
 		Description: `
 		Create and manage an API Policy of type message-logging.
 		`,
@@ -41,15 +42,17 @@ func resourceApimInstancePolicyMessageLogging() *schema.Resource {
 			},
 			"org_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The organization id where the api instance is defined.",
+				Description: "The organization id where the api instance is defined. Defaults to the provider's default_org_id.",
 			},
 			"env_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The environment id where api instance is defined.",
+				Description: "The environment id where api instance is defined. Defaults to the provider's default_env_id or default_env_name.",
 			},
 			"audit": {
 				Type:        schema.TypeMap,
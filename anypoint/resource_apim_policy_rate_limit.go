@@ -20,6 +20,7 @@ func resourceApimInstancePolicyRateLimiting() *schema.Resource {
 		ReadContext:   resourceApimInstancePolicyRateLimitingRead,
 		UpdateContext: resourceApimInstancePolicyRateLimitingUpdate,
 		DeleteContext: resourceApimInstancePolicyRateLimitingDelete,
+		CustomizeDiff: customizeDiffProviderDefaults,
 		Description: `
 		Create and manage an API Policy of type rate limiting.
 		`,
@@ -43,15 +44,17 @@ func resourceApimInstancePolicyRateLimiting() *schema.Resource {
 			},
 			"org_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The organization id where the api instance is defined.",
+				Description: "The organization id where the api instance is defined. Defaults to the provider's default_org_id.",
 			},
 			"env_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The environment id where api instance is defined.",
+				Description: "The environment id where api instance is defined. Defaults to the provider's default_env_id or default_env_name.",
 			},
 			"audit": {
 				Type:        schema.TypeMap,
@@ -103,7 +103,7 @@ func resourceBG() *schema.Resource {
 			"mfa_required": {
 				Type:        schema.TypeString,
 				Computed:    true,
-				Description: "Whether MFA is enforced in this organization",
+				Description: "Whether MFA is enforced in this organization. Use the resource anypoint_org_security_settings to manage it.",
 			},
 			"is_automatic_admin_promotion_exempt": {
 				Type:        schema.TypeBool,
@@ -770,11 +770,17 @@ func resourceBG() *schema.Resource {
 			"session_timeout": {
 				Type:        schema.TypeInt,
 				Optional:    true,
-				Description: "The organization's session timeout",
+				Description: "The organization's session timeout, left unchanged when not set. Don't set it if the session_timeout is managed with anypoint_org_security_settings, the two resources would overwrite each other.",
 				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
 					return DiffSuppressFunc4OptionalPrimitives(k, old, new, d, "0") // default value of integeres if not set is 0
 				},
 			},
+			"deletion_protection": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the business group is protected from deletion. While enabled, the business group cannot be destroyed or replaced, it has to be set to false in an apply first.",
+			},
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -883,6 +889,9 @@ func resourceBGUpdate(ctx context.Context, d *schema.ResourceData, m interface{}
 
 func resourceBGDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
+	if diags := checkDeletionProtection(d, "Unable to Delete Business Group"); diags != nil {
+		return diags
+	}
 	pco := m.(ProviderConfOutput)
 	orgid := d.Id()
 	authctx := getBGAuthCtx(ctx, &pco)
@@ -934,7 +943,10 @@ func newBGPutBody(d *schema.ResourceData) *org.BGPutReqBody {
 	body.SetName(d.Get("name").(string))
 	body.SetOwnerId(d.Get("owner_id").(string))
 	body.SetEntitlements(*newEntitlementsFromD(d))
-	body.SetSessionTimeout(int32(d.Get("session_timeout").(int)))
+	// the session timeout is left unchanged when not set, it may be managed with anypoint_org_security_settings
+	if val, ok := d.GetOk("session_timeout"); ok {
+		body.SetSessionTimeout(int32(val.(int)))
+	}
 
 	return body
 }
@@ -977,6 +989,6 @@ func newEntitlementsFromD(d *schema.ResourceData) *org.EntitlementsCore {
  * Returns authentication context (includes authorization header)
  */
 func getBGAuthCtx(ctx context.Context, pco *ProviderConfOutput) context.Context {
-	tmp := context.WithValue(ctx, org.ContextAccessToken, pco.access_token)
+	tmp := context.WithValue(ctx, org.ContextAccessToken, pco.tokensource.Token(ctx))
 	return context.WithValue(tmp, org.ContextServerIndex, pco.server_index)
 }
@@ -0,0 +1,482 @@
+package anypoint
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type clientProvider struct {
+	ProviderId                      string              `json:"provider_id,omitempty"`
+	OrgId                           string              `json:"org_id,omitempty"`
+	Name                            string              `json:"name"`
+	Description                     string              `json:"description,omitempty"`
+	Type                            *clientProviderType `json:"type,omitempty"`
+	AllowUntrustedCertificates      bool                `json:"allow_untrusted_certificates"`
+	AllowClientImport               bool                `json:"allow_client_import"`
+	AllowExternalClientModification bool                `json:"allow_external_client_modification"`
+	AllowLocalClientDeletion        bool                `json:"allow_local_client_deletion"`
+	OidcDynamicClientProvider       *clientProviderOidc `json:"oidc_dynamic_client_provider,omitempty"`
+	Environments                    []string            `json:"environments"`
+}
+
+type clientProviderType struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type clientProviderOidc struct {
+	Issuer           string                     `json:"issuer"`
+	RegistrationAuth string                     `json:"registration_auth,omitempty"`
+	Urls             *clientProviderOidcUrls    `json:"urls,omitempty"`
+	Client           *clientProviderOidcClient  `json:"client,omitempty"`
+	Timeouts         *clientProviderOidcTimeout `json:"timeouts,omitempty"`
+}
+
+type clientProviderOidcUrls struct {
+	Register   string `json:"register"`
+	Introspect string `json:"introspect"`
+	Authorize  string `json:"authorize"`
+	Token      string `json:"token"`
+}
+
+type clientProviderOidcClient struct {
+	Credentials *clientProviderOidcCredentials `json:"credentials,omitempty"`
+}
+
+type clientProviderOidcCredentials struct {
+	Id     string `json:"id"`
+	Secret string `json:"secret,omitempty"`
+}
+
+type clientProviderOidcTimeout struct {
+	Connection int `json:"connection"`
+	Read       int `json:"read"`
+}
+
+func resourceClientProvider() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceClientProviderCreate,
+		ReadContext:   resourceClientProviderRead,
+		UpdateContext: resourceClientProviderUpdate,
+		DeleteContext: resourceClientProviderDelete,
+		CustomizeDiff: customizeDiffProviderDefaults,
+		Description: `
+		Creates an OpenID Connect dynamic client registration ` + "`" + `client provider` + "`" + ` in your organization.
+		Client providers are referenced by api manager instances (provider_id) to manage the api's client applications.
+		`,
+		Schema: map[string]*schema.Schema{
+			"last_updated": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The last time this resource has been updated locally.",
+			},
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The unique id of this client provider generated by the anypoint platform.",
+			},
+			"org_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The master organization id where the client provider is defined. Defaults to the provider's default_org_id.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the client provider.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The description of the client provider.",
+			},
+			"type": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "The type of the client provider, contains description and the name of the type of the provider.",
+			},
+			"issuer": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsURLWithHTTPorHTTPS),
+				Description:      "The openid-connect provider's token issuer url.",
+			},
+			"registration_url": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsURLWithHTTPorHTTPS),
+				Description:      "The openid-connect provider's dynamic client registration url.",
+			},
+			"introspection_url": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsURLWithHTTPorHTTPS),
+				Description:      "The openid-connect provider's token introspection url.",
+			},
+			"authorize_url": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsURLWithHTTPorHTTPS),
+				Description:      "The openid-connect provider's authorization url.",
+			},
+			"token_url": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsURLWithHTTPorHTTPS),
+				Description:      "The openid-connect provider's token url.",
+			},
+			"client_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The id of the client used by anypoint to introspect tokens.",
+			},
+			"client_secret": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "The secret of the client used by anypoint to introspect tokens.",
+			},
+			"registration_auth": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The authorization header value (i.e. Bearer initial-access-token) sent to the registration url when registering clients.",
+			},
+			"connection_timeout": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          5000,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+				Description:      "The timeout in milliseconds for opening a connection to the openid-connect provider.",
+			},
+			"read_timeout": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          5000,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+				Description:      "The timeout in milliseconds for reading the openid-connect provider's responses.",
+			},
+			"allow_untrusted_certificates": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether untrusted certificates are accepted when connecting to the openid-connect provider.",
+			},
+			"allow_client_import": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether clients created outside of anypoint can be imported.",
+			},
+			"allow_external_client_modification": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether clients created outside of anypoint can be modified.",
+			},
+			"allow_local_client_deletion": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether clients deleted in anypoint are only deleted locally (not in the openid-connect provider).",
+			},
+			"environment_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The list of environment ids the client provider is assigned to.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceClientProviderCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid := d.Get("org_id").(string)
+	authctx := getAccountsAuthCtx(ctx, &pco)
+	//prepare request
+	body := newClientProviderBody(d)
+	//perform request
+	var res clientProvider
+	httpr, err := pco.accountsclient.Execute(authctx, http.MethodPost, getClientProvidersPath(orgid), body, &res)
+	if err != nil {
+		var details string
+		if httpr != nil && httpr.StatusCode >= 400 {
+			defer httpr.Body.Close()
+			b, _ := io.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to create client provider for org " + orgid,
+			Detail:   details,
+		})
+		return diags
+	}
+	defer httpr.Body.Close()
+	d.SetId(res.ProviderId)
+	return resourceClientProviderRead(ctx, d, m)
+}
+
+func resourceClientProviderRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	providerid := d.Id()
+	orgid := d.Get("org_id").(string)
+	if isComposedResourceId(providerid) {
+		orgid, providerid = decomposeClientProviderId(d)
+	}
+	authctx := getAccountsAuthCtx(ctx, &pco)
+	//perform request
+	var res clientProvider
+	httpr, err := pco.accountsclient.Execute(authctx, http.MethodGet, getClientProviderPath(orgid, providerid), nil, &res)
+	if err != nil {
+		var details string
+		if httpr != nil && httpr.StatusCode >= 400 {
+			defer httpr.Body.Close()
+			b, _ := io.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		diags := append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to read client provider " + providerid + " in org " + orgid,
+			Detail:   details,
+		})
+		return diags
+	}
+	defer httpr.Body.Close()
+	//process data
+	data := flattenClientProviderData(&res)
+	//save in data source schema
+	if err := setClientProviderAttributesToResourceData(d, data); err != nil {
+		diags := append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to set client provider " + providerid + " in org " + orgid,
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	d.SetId(providerid)
+	d.Set("org_id", orgid)
+	return diags
+}
+
+func resourceClientProviderUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	providerid := d.Id()
+	orgid := d.Get("org_id").(string)
+	//check for changes
+	if d.HasChanges(getClientProviderUpdatableAttributes()...) {
+		authctx := getAccountsAuthCtx(ctx, &pco)
+		//prepare request
+		body := newClientProviderBody(d)
+		body.Type = nil
+		//perform request
+		httpr, err := pco.accountsclient.Execute(authctx, http.MethodPatch, getClientProviderPath(orgid, providerid), body, nil)
+		if err != nil {
+			var details string
+			if httpr != nil && httpr.StatusCode >= 400 {
+				defer httpr.Body.Close()
+				b, _ := io.ReadAll(httpr.Body)
+				details = string(b)
+			} else {
+				details = err.Error()
+			}
+			diags := append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to update client provider " + providerid + " in org " + orgid,
+				Detail:   details,
+			})
+			return diags
+		}
+		defer httpr.Body.Close()
+		d.Set("last_updated", time.Now().Format(time.RFC850))
+		return resourceClientProviderRead(ctx, d, m)
+	}
+	return diags
+}
+
+func resourceClientProviderDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	providerid := d.Id()
+	orgid := d.Get("org_id").(string)
+	authctx := getAccountsAuthCtx(ctx, &pco)
+	//perform request
+	httpr, err := pco.accountsclient.Execute(authctx, http.MethodDelete, getClientProviderPath(orgid, providerid), nil, nil)
+	if err != nil {
+		var details string
+		if httpr != nil && httpr.StatusCode >= 400 {
+			defer httpr.Body.Close()
+			b, _ := io.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		diags := append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to delete client provider " + providerid + " in org " + orgid,
+			Detail:   details,
+		})
+		return diags
+	}
+	defer httpr.Body.Close()
+	// d.SetId("") is automatically called assuming delete returns no errors, but
+	// it is added here for explicitness.
+	d.SetId("")
+	return diags
+}
+
+/* Prepares the body required to create or update a client provider */
+func newClientProviderBody(d *schema.ResourceData) *clientProvider {
+	body := &clientProvider{
+		Name:                            d.Get("name").(string),
+		Description:                     d.Get("description").(string),
+		AllowUntrustedCertificates:      d.Get("allow_untrusted_certificates").(bool),
+		AllowClientImport:               d.Get("allow_client_import").(bool),
+		AllowExternalClientModification: d.Get("allow_external_client_modification").(bool),
+		AllowLocalClientDeletion:        d.Get("allow_local_client_deletion").(bool),
+		Type: &clientProviderType{
+			Name:        "openid",
+			Description: "OpenID Connect Dynamic Client Registration",
+		},
+		OidcDynamicClientProvider: &clientProviderOidc{
+			Issuer:           d.Get("issuer").(string),
+			RegistrationAuth: d.Get("registration_auth").(string),
+			Urls: &clientProviderOidcUrls{
+				Register:   d.Get("registration_url").(string),
+				Introspect: d.Get("introspection_url").(string),
+				Authorize:  d.Get("authorize_url").(string),
+				Token:      d.Get("token_url").(string),
+			},
+			Client: &clientProviderOidcClient{
+				Credentials: &clientProviderOidcCredentials{
+					Id:     d.Get("client_id").(string),
+					Secret: d.Get("client_secret").(string),
+				},
+			},
+			Timeouts: &clientProviderOidcTimeout{
+				Connection: d.Get("connection_timeout").(int),
+				Read:       d.Get("read_timeout").(int),
+			},
+		},
+		Environments: make([]string, 0),
+	}
+	if val, ok := d.GetOk("environment_ids"); ok {
+		body.Environments = ListInterface2ListStrings(val.(*schema.Set).List())
+	}
+	return body
+}
+
+/*
+* Transforms a client provider object to the resource schema
+ */
+func flattenClientProviderData(provider *clientProvider) map[string]interface{} {
+	item := make(map[string]interface{})
+	if provider == nil {
+		return item
+	}
+	item["id"] = provider.ProviderId
+	item["name"] = provider.Name
+	item["description"] = provider.Description
+	if t := provider.Type; t != nil {
+		item["type"] = map[string]interface{}{
+			"name":        t.Name,
+			"description": t.Description,
+		}
+	}
+	item["allow_untrusted_certificates"] = provider.AllowUntrustedCertificates
+	item["allow_client_import"] = provider.AllowClientImport
+	item["allow_external_client_modification"] = provider.AllowExternalClientModification
+	item["allow_local_client_deletion"] = provider.AllowLocalClientDeletion
+	item["environment_ids"] = provider.Environments
+	if oidc := provider.OidcDynamicClientProvider; oidc != nil {
+		item["issuer"] = oidc.Issuer
+		if urls := oidc.Urls; urls != nil {
+			item["registration_url"] = urls.Register
+			item["introspection_url"] = urls.Introspect
+			item["authorize_url"] = urls.Authorize
+			item["token_url"] = urls.Token
+		}
+		if client := oidc.Client; client != nil && client.Credentials != nil {
+			item["client_id"] = client.Credentials.Id
+		}
+		if timeouts := oidc.Timeouts; timeouts != nil {
+			item["connection_timeout"] = timeouts.Connection
+			item["read_timeout"] = timeouts.Read
+		}
+	}
+	return item
+}
+
+/*
+* Copies the given client provider into the given resource data
+ */
+func setClientProviderAttributesToResourceData(d *schema.ResourceData, data map[string]interface{}) error {
+	attributes := getClientProviderAttributes()
+	if data != nil {
+		for _, attr := range attributes {
+			if val, ok := data[attr]; ok {
+				if err := d.Set(attr, val); err != nil {
+					return fmt.Errorf("unable to set client provider attribute %s\n details: %s", attr, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func getClientProviderAttributes() []string {
+	attributes := [...]string{
+		"name", "description", "type", "issuer", "registration_url", "introspection_url",
+		"authorize_url", "token_url", "client_id", "connection_timeout", "read_timeout",
+		"allow_untrusted_certificates", "allow_client_import", "allow_external_client_modification",
+		"allow_local_client_deletion", "environment_ids",
+	}
+	return attributes[:]
+}
+
+func getClientProviderUpdatableAttributes() []string {
+	attributes := [...]string{
+		"name", "description", "issuer", "registration_url", "introspection_url",
+		"authorize_url", "token_url", "client_id", "client_secret", "registration_auth",
+		"connection_timeout", "read_timeout", "allow_untrusted_certificates", "allow_client_import",
+		"allow_external_client_modification", "allow_local_client_deletion", "environment_ids",
+	}
+	return attributes[:]
+}
+
+func getClientProvidersPath(orgid string) string {
+	return fmt.Sprintf("/organizations/%s/clientProviders", url.PathEscape(orgid))
+}
+
+func getClientProviderPath(orgid, providerid string) string {
+	return getClientProvidersPath(orgid) + "/" + url.PathEscape(providerid)
+}
+
+func decomposeClientProviderId(d *schema.ResourceData) (string, string) {
+	s := DecomposeResourceId(d.Id())
+	return s[0], s[1]
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net/http"
 
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -127,16 +128,16 @@ var DeplApplicationC2SSDefinition = &schema.Resource{
 			Description: "The status of the application.",
 		},
 		"desired_state": {
-			Type:        schema.TypeString,
-			Optional:    true,
-			Default:     "STARTED",
-			Description: "The desired state of the application.",
+			Type:     schema.TypeString,
+			Optional: true,
+			Default:  APP_DEPLOYMENT_V2_APP_STATE_STARTED,
+			Description: `
+			The desired state of the application: STARTED or STOPPED.
+			When it is the only change, the application is started or stopped without being redeployed.
+			`,
 			ValidateDiagFunc: validation.ToDiagFunc(
 				validation.StringInSlice(
-					[]string{
-						"PARTIALLY_STARTED", "DEPLOYMENT_FAILED", "STARTING", "STARTED", "STOPPING",
-						"STOPPED", "UNDEPLOYING", "UNDEPLOYED", "UPDATED", "APPLIED", "APPLYING", "FAILED", "DELETED",
-					},
+					[]string{APP_DEPLOYMENT_V2_APP_STATE_STARTED, APP_DEPLOYMENT_V2_APP_STATE_STOPPED},
 					false,
 				),
 			),
@@ -147,7 +148,7 @@ var DeplApplicationC2SSDefinition = &schema.Resource{
 			Required: true,
 			Description: `
 			The reference to the artifact on Exchange that is to be deployed on Cloudhub 2.0.
-			Please ensure the application's artifact is deployed on Exchange before using this resource on Cloudhub 2.0.
+			Please ensure the application's artifact is deployed on Exchange before using this resource on Cloudhub 2.0, or use artifact_path to publish a local artifact.
 			`,
 			Elem: DeplApplicationRefC2SSDefinition,
 		},
@@ -448,8 +449,18 @@ func resourceCloudhub2SharedSpaceDeployment() *schema.Resource {
 		ReadContext:   resourceCloudhub2SharedSpaceDeploymentRead,
 		UpdateContext: resourceCloudhub2SharedSpaceDeploymentUpdate,
 		DeleteContext: resourceCloudhub2SharedSpaceDeploymentDelete,
+		CustomizeDiff: func(ctx context.Context, rd *schema.ResourceDiff, i interface{}) error {
+			if err := customizeDiffProviderDefaults(ctx, rd, i); err != nil {
+				return err
+			}
+			return customizeDiffArtifactHash(rd)
+		},
 		Description: `
 		Creates and manages a ` + "`" + `deployment` + "`" + ` of a mule app on Cloudhub v2 Shared-Space only.
+		Create and update operations wait until the deployment is applied and the application is running, the latest log lines of the deployment are reported in case of failure.
+		The application can be started, stopped or restarted (restart_trigger) without being redeployed.
+		Changes are rolled out in place, or with a candidate deployment taking over the public url once running (canary) or once promoted (blue_green).
+		Failed rollouts are rolled back automatically to the last successful version unless rollout.auto_rollback is false.
 		`,
 		Schema: map[string]*schema.Schema{
 			"id": {
@@ -459,15 +470,17 @@ func resourceCloudhub2SharedSpaceDeployment() *schema.Resource {
 			},
 			"org_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The organization where the mule app is deployed.",
+				Description: "The organization where the mule app is deployed. Defaults to the provider's default_org_id.",
 			},
 			"env_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The environment where mule app is deployed.",
+				Description: "The environment where mule app is deployed. Defaults to the provider's default_env_id or default_env_name.",
 			},
 			"name": {
 				Type:        schema.TypeString,
@@ -520,6 +533,44 @@ func resourceCloudhub2SharedSpaceDeployment() *schema.Resource {
 				Description: "The last successfully deployed version",
 				Computed:    true,
 			},
+			"artifact_path": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: `
+				The path of a local mule application jar file.
+				The file is published to Exchange under the group_id, artifact_id and version of the application ref before being deployed.
+				Exchange versions are immutable, the version has to be incremented when the content of the file changes.
+				`,
+			},
+			"artifact_hash": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The sha256 checksum of the local application jar file given by artifact_path.",
+			},
+			"rollout": {
+				Type:        schema.TypeList,
+				MaxItems:    1,
+				Optional:    true,
+				Description: "The rollout of the changes of the deployment.",
+				Elem:        DeplRolloutDefinition,
+			},
+			"restart_trigger": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Arbitrary map of values that, when changed, performs a rolling restart of the replicas of the application without redeploying it. The restart is skipped with a warning when the application is not STARTED or when the deployment has other changes.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"candidate_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The id of the candidate deployment waiting for its promotion (blue_green strategy) or kept after a failed rollout without auto_rollback.",
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(APP_DEPLOYMENT_V2_DEFAULT_TIMEOUT),
+			Update: schema.DefaultTimeout(APP_DEPLOYMENT_V2_DEFAULT_TIMEOUT),
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -533,6 +584,15 @@ func resourceCloudhub2SharedSpaceDeploymentCreate(ctx context.Context, d *schema
 	name := d.Get("name").(string)
 	orgid := d.Get("org_id").(string)
 	envid := d.Get("env_id").(string)
+	//publish the local artifact
+	if err := publishAppDeploymentV2Artifact(ctx, &pco, d); err != nil {
+		diags := append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to publish the artifact of " + name + " deployment to exchange.",
+			Detail:   err.Error(),
+		})
+		return diags
+	}
 	authctx := getAppDeploymentV2AuthCtx(ctx, &pco)
 	body := newCloudhub2SharedSpaceDeploymentBody(d)
 	//Execute post deployment
@@ -555,6 +615,15 @@ func resourceCloudhub2SharedSpaceDeploymentCreate(ctx context.Context, d *schema
 	}
 	defer httpr.Body.Close()
 	d.SetId(res.GetId())
+	//wait for the deployment to be applied
+	if _, err := waitAppDeploymentV2Ready(ctx, &pco, orgid, envid, res.GetId(), d.Timeout(schema.TimeoutCreate)); err != nil {
+		diags := append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to create " + name + " deployment for cloudhub 2.0 shared-space.",
+			Detail:   err.Error() + getAppDeploymentV2LogsTail(ctx, &pco, orgid, envid, res.GetId(), res.GetDesiredVersion()),
+		})
+		return diags
+	}
 	return resourceCloudhub2SharedSpaceDeploymentRead(ctx, d, m)
 }
 
@@ -567,9 +636,22 @@ func resourceCloudhub2SharedSpaceDeploymentRead(ctx context.Context, d *schema.R
 	if isComposedResourceId(id) {
 		orgid, envid, id = decomposeCloudhub2SharedSpaceDeploymentId(d)
 	}
+	name := d.Get("name").(string)
+	// the attributes of a pending candidate are the ones of the configuration
+	readid := id
+	if candidateid := d.Get("candidate_id").(string); candidateid != "" {
+		readid = candidateid
+	}
 	authctx := getAppDeploymentV2AuthCtx(ctx, &pco)
 	//perform request
-	res, httpr, err := pco.appmanagerclient.DefaultApi.GetDeploymentById(authctx, orgid, envid, id).Execute()
+	res, httpr, err := pco.appmanagerclient.DefaultApi.GetDeploymentById(authctx, orgid, envid, readid).Execute()
+	if err != nil && readid != id && httpr != nil && httpr.StatusCode == http.StatusNotFound {
+		// the candidate has been deleted by a rolled back promotion or outside of terraform
+		httpr.Body.Close()
+		d.Set("candidate_id", "")
+		readid = id
+		res, httpr, err = pco.appmanagerclient.DefaultApi.GetDeploymentById(authctx, orgid, envid, readid).Execute()
+	}
 	if err != nil {
 		var details string
 		if httpr != nil && httpr.StatusCode >= 400 {
@@ -581,7 +663,7 @@ func resourceCloudhub2SharedSpaceDeploymentRead(ctx context.Context, d *schema.R
 		}
 		diags := append(diags, diag.Diagnostic{
 			Severity: diag.Error,
-			Summary:  "Unable to read cloudhub2 deployment " + id + " on shared-space.",
+			Summary:  "Unable to read cloudhub2 deployment " + readid + " on shared-space.",
 			Detail:   details,
 		})
 		return diags
@@ -598,8 +680,9 @@ func resourceCloudhub2SharedSpaceDeploymentRead(ctx context.Context, d *schema.R
 		})
 		return diags
 	}
+	restoreAppDeploymentV2Name(d, name)
 	// setting all params required for reading in case of import
-	d.SetId(res.GetId())
+	d.SetId(id)
 	d.Set("org_id", orgid)
 	d.Set("env_id", envid)
 
@@ -612,31 +695,21 @@ func resourceCloudhub2SharedSpaceDeploymentUpdate(ctx context.Context, d *schema
 		return diags
 	}
 	pco := m.(ProviderConfOutput)
-	id := d.Id()
-	orgid := d.Get("org_id").(string)
-	envid := d.Get("env_id").(string)
 	name := d.Get("name").(string)
-	authctx := getAppDeploymentV2AuthCtx(ctx, &pco)
-	body := newCloudhub2SharedSpaceDeploymentBody(d)
-	_, httpr, err := pco.appmanagerclient.DefaultApi.PatchDeployment(authctx, orgid, envid, id).DeploymentRequestBody(*body).Execute()
-	if err != nil {
-		var details string
-		if httpr != nil && httpr.StatusCode >= 400 {
-			defer httpr.Body.Close()
-			b, _ := io.ReadAll(httpr.Body)
-			details = string(b)
-		} else {
-			details = err.Error()
-		}
+	//publish the local artifact
+	if err := publishAppDeploymentV2Artifact(ctx, &pco, d); err != nil {
 		diags := append(diags, diag.Diagnostic{
 			Severity: diag.Error,
-			Summary:  "Unable to update deployment " + name + " on cloudhub 2.0 shared-space.",
-			Detail:   details,
+			Summary:  "Unable to publish the artifact of " + name + " deployment to exchange.",
+			Detail:   err.Error(),
 		})
 		return diags
 	}
-	defer httpr.Body.Close()
-	return resourceCloudhub2SharedSpaceDeploymentRead(ctx, d, m)
+	diags = append(diags, updateAppDeploymentV2(ctx, d, &pco, newCloudhub2SharedSpaceDeploymentBodyFromConfig, "Unable to update deployment "+name+" on cloudhub 2.0 shared-space.")...)
+	if diags.HasError() {
+		return diags
+	}
+	return append(diags, resourceCloudhub2SharedSpaceDeploymentRead(ctx, d, m)...)
 }
 
 func resourceCloudhub2SharedSpaceDeploymentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -646,6 +719,18 @@ func resourceCloudhub2SharedSpaceDeploymentDelete(ctx context.Context, d *schema
 	orgid := d.Get("org_id").(string)
 	envid := d.Get("env_id").(string)
 	name := d.Get("name").(string)
+	//delete the pending candidate first
+	if candidateid := d.Get("candidate_id").(string); candidateid != "" {
+		if err := deleteAppDeploymentV2(ctx, &pco, orgid, envid, candidateid); err != nil {
+			diags := append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to delete candidate deployment of " + name + " on cloudhub 2.0 shared-space.",
+				Detail:   err.Error(),
+			})
+			return diags
+		}
+		d.Set("candidate_id", "")
+	}
 	authctx := getAppDeploymentV2AuthCtx(ctx, &pco)
 	httpr, err := pco.appmanagerclient.DefaultApi.DeleteDeployment(authctx, orgid, envid, id).Execute()
 	if err != nil {
@@ -673,17 +758,22 @@ func resourceCloudhub2SharedSpaceDeploymentDelete(ctx context.Context, d *schema
 
 // Prepares Deployment Post Body out of resource data input
 func newCloudhub2SharedSpaceDeploymentBody(d *schema.ResourceData) *application_manager_v2.DeploymentRequestBody {
-	body := application_manager_v2.NewDeploymentRequestBody()
-	// -- Parsing Application
 	app_list_d := d.Get("application").([]interface{})
 	app_d := app_list_d[0].(map[string]interface{})
-	application := newCloudhub2SharedSpaceDeploymentApplication(app_d)
-	// -- Parsing Target
 	target_list_d := d.Get("target").([]interface{})
 	target_d := target_list_d[0].(map[string]interface{})
+	return newCloudhub2SharedSpaceDeploymentBodyFromConfig(d.Get("name").(string), app_d, target_d)
+}
+
+// Prepares Deployment Body of the given name out of application and target inputs
+func newCloudhub2SharedSpaceDeploymentBodyFromConfig(name string, app_d map[string]interface{}, target_d map[string]interface{}) *application_manager_v2.DeploymentRequestBody {
+	body := application_manager_v2.NewDeploymentRequestBody()
+	// -- Parsing Application
+	application := newCloudhub2SharedSpaceDeploymentApplication(app_d)
+	// -- Parsing Target
 	target := newCloudhub2SharedSpaceDeploymentTarget(target_d)
 	//Set Body Data
-	body.SetName(d.Get("name").(string))
+	body.SetName(name)
 	body.SetApplication(*application)
 	body.SetTarget(*target)
 
@@ -869,6 +959,6 @@ func decomposeCloudhub2SharedSpaceDeploymentId(d *schema.ResourceData) (string,
 }
 
 func getCloudhub2SharedSpaceDeploymentUpdatableAttributes() []string {
-	attributes := [...]string{"application", "target"}
+	attributes := [...]string{"application", "target", "artifact_path", "artifact_hash", "rollout", "restart_trigger"}
 	return attributes[:]
 }
@@ -3,10 +3,14 @@ package anypoint
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
+	"log"
 	"net/http"
+	"net/url"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -23,7 +27,18 @@ func resourceConnectedApp() *schema.Resource {
 		DeleteContext: resourceConnectedAppDelete,
 		Description: `
 		Creates and manage a ` + "`" + `connected app` + "`" + `.
+		The client secret can be rotated using rotation_trigger or rotate_after_days, the platform then regenerates the secret of the connected app and the new secret is stored in client_secret.
+		WARNING: there is no grace window, the previous secret stops working as soon as it is regenerated. Every consumer of the connected app fails to authenticate until it is updated with the new secret (client_secret), plan the rotation accordingly.
 		`,
+		CustomizeDiff: func(ctx context.Context, rd *schema.ResourceDiff, i interface{}) error {
+			if err := customizeDiffProviderDefaults(ctx, rd, i); err != nil {
+				return err
+			}
+			if err := customizeDiffConnectedAppScopes(ctx, rd, i); err != nil {
+				return err
+			}
+			return customizeDiffConnectedAppRotation(ctx, rd, i)
+		},
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Type:        schema.TypeString,
@@ -32,9 +47,10 @@ func resourceConnectedApp() *schema.Resource {
 			},
 			"org_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The organization id where the connected app's owner is defined.",
+				Description: "The organization id where the connected app's owner is defined. Defaults to the provider's default_org_id.",
 			},
 			"name": {
 				Type:        schema.TypeString,
@@ -54,6 +70,30 @@ func resourceConnectedApp() *schema.Resource {
 					}
 				},
 			},
+			"rotation_trigger": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"secret"},
+				Description:   "Arbitrary value that, when changed, rotates the secret of the connected app.",
+			},
+			"rotate_after_days": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ConflictsWith:    []string{"secret"},
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+				Description:      "The number of days after which the secret of the connected app is rotated. The rotation happens on the first apply after the secret has expired.",
+			},
+			"client_secret": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The current secret of the connected app, updated whenever the secret is rotated.",
+			},
+			"secret_rotated_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time (RFC3339) at which the secret was last rotated, or the creation time of the connected app if it has never been rotated. Empty for imported connected apps until their secret is rotated with rotation_trigger, rotate_after_days has no effect until then.",
+			},
 			"user_id": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -98,7 +138,7 @@ func resourceConnectedApp() *schema.Resource {
 				},
 			},
 			"scope": {
-				Description: "The scopes this connected app has authorization to work on",
+				Description: "The scopes this connected app has authorization to work on. The scopes and their required context params are validated at plan time, see the data source anypoint_connected_app_scopes.",
 				Type:        schema.TypeList,
 				Optional:    true,
 				DefaultFunc: func() (interface{}, error) {
@@ -206,6 +246,7 @@ func resourceConnectedAppCreate(ctx context.Context, d *schema.ResourceData, m i
 	}
 	defer httpr.Body.Close()
 	d.SetId(res.GetClientId())
+	d.Set("secret_rotated_at", time.Now().UTC().Format(time.RFC3339))
 	// Is it a "on its own behalf" connected apps?
 	if grant_types, ok := body.GetGrantTypesOk(); ok && StringInSlice(grant_types, "client_credentials", true) {
 		// Are there scopes to be saved?
@@ -290,6 +331,7 @@ func resourceConnectedAppRead(ctx context.Context, d *schema.ResourceData, m int
 	}
 	d.SetId(connappid)
 	d.Set("org_id", orgid)
+	d.Set("client_secret", res.GetClientSecret())
 	return diags
 }
 
@@ -299,27 +341,44 @@ func resourceConnectedAppUpdate(ctx context.Context, d *schema.ResourceData, m i
 	orgid := d.Get("org_id").(string)
 	connappid := d.Id()
 	authctx := getConnectedAppAuthCtx(ctx, &pco)
-	if d.HasChanges(getConnectedAppAttributes()...) {
+	// the rotation is decided at plan time, see customizeDiffConnectedAppRotation
+	rotate := isConnectedAppSecretRotationPlanned(d)
+	if d.HasChanges(getConnectedAppAttributes()...) || rotate {
 		body := newConnectedAppPatchBody(d)
-		//perform request
-		_, httpr, err := pco.connectedappclient.DefaultApi.UpdateConnectedApp(authctx, orgid, connappid).ConnectedAppPatchExt(*body).Execute()
-		if err != nil {
-			var details string
-			if httpr != nil && httpr.StatusCode >= 400 {
-				defer httpr.Body.Close()
-				b, _ := io.ReadAll(httpr.Body)
-				details = string(b)
-			} else {
-				details = err.Error()
+		if d.HasChanges(getConnectedAppAttributes()...) {
+			//perform request
+			_, httpr, err := pco.connectedappclient.DefaultApi.UpdateConnectedApp(authctx, orgid, connappid).ConnectedAppPatchExt(*body).Execute()
+			if err != nil {
+				var details string
+				if httpr != nil && httpr.StatusCode >= 400 {
+					defer httpr.Body.Close()
+					b, _ := io.ReadAll(httpr.Body)
+					details = string(b)
+				} else {
+					details = err.Error()
+				}
+				diags := append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Unable to update connected-app " + connappid,
+					Detail:   details,
+				})
+				return diags
 			}
-			diags := append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to update connected-app " + connappid,
-				Detail:   details,
-			})
-			return diags
+			defer httpr.Body.Close()
+		}
+		if rotate {
+			secret, err := regenerateConnectedAppSecret(ctx, &pco, orgid, connappid)
+			if err != nil {
+				diags := append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Unable to regenerate the secret of connected-app " + connappid,
+					Detail:   err.Error(),
+				})
+				return diags
+			}
+			d.Set("client_secret", secret)
+			d.Set("secret_rotated_at", time.Now().UTC().Format(time.RFC3339))
 		}
-		defer httpr.Body.Close()
 		// Is it a "on its own behalf" connected apps?
 		if grant_types, ok := body.GetGrantTypesOk(); ok && StringInSlice(grant_types, "client_credentials", true) {
 			// Are there scopes to be saved?
@@ -523,6 +582,114 @@ func newConnectedAppPatchBody(d *schema.ResourceData) *connected_app.ConnectedAp
 	return body
 }
 
+// validates at plan time that the scopes exist in the catalog and that the required context params are provided
+func customizeDiffConnectedAppScopes(ctx context.Context, rd *schema.ResourceDiff, m interface{}) error {
+	if !rd.HasChange("scope") || !rd.NewValueKnown("scope") {
+		return nil
+	}
+	scopes := rd.Get("scope").([]interface{})
+	if len(scopes) == 0 {
+		return nil
+	}
+	pco := m.(ProviderConfOutput)
+	catalog, err := getConnectedAppScopesCatalog(ctx, &pco)
+	if err != nil {
+		log.Printf("[WARN] skipping connected app scopes validation: %s", err)
+		return nil
+	}
+	// context params are only sent for "on its own behalf" connected apps
+	ownBehalf := StringInSlice(ListInterface2ListStrings(rd.Get("grant_types").([]interface{})), "client_credentials", true)
+	errs := make([]string, 0)
+	for i, item := range scopes {
+		if item == nil {
+			continue
+		}
+		scope := item.(map[string]interface{})
+		name := scope["scope"].(string)
+		if !rd.NewValueKnown(fmt.Sprintf("scope.%d.scope", i)) || strings.EqualFold(name, "profile") {
+			continue
+		}
+		info, ok := catalog[name]
+		if !ok {
+			errs = append(errs, fmt.Sprintf("scope \"%s\" does not exist", name))
+			continue
+		}
+		if !ownBehalf {
+			continue
+		}
+		if info.requiresContextParam(CONNECTED_APP_SCOPE_CTX_ORG) && rd.NewValueKnown(fmt.Sprintf("scope.%d.org_id", i)) && scope["org_id"].(string) == "" {
+			errs = append(errs, fmt.Sprintf("scope \"%s\" requires org_id", name))
+		}
+		if info.requiresContextParam(CONNECTED_APP_SCOPE_CTX_ENV) && rd.NewValueKnown(fmt.Sprintf("scope.%d.env_id", i)) && scope["env_id"].(string) == "" {
+			errs = append(errs, fmt.Sprintf("scope \"%s\" requires env_id", name))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid connected app scopes, see the data source anypoint_connected_app_scopes for the available scopes:\n\t%s", strings.Join(errs, "\n\t"))
+	}
+	return nil
+}
+
+// plans the rotation of the secret when the rotation trigger changes or the secret has expired
+func customizeDiffConnectedAppRotation(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	if isConnectedAppSecretRotationDue(d.HasChange("rotation_trigger"), d.Get("secret_rotated_at").(string), d.Get("rotate_after_days").(int)) {
+		if err := d.SetNewComputed("client_secret"); err != nil {
+			return err
+		}
+		return d.SetNewComputed("secret_rotated_at")
+	}
+	return nil
+}
+
+// returns true if the secret has to be rotated, either the trigger has changed or the secret is older than the given number of days
+func isConnectedAppSecretRotationDue(triggerChanged bool, rotatedAt string, days int) bool {
+	if triggerChanged {
+		return true
+	}
+	if days <= 0 || rotatedAt == "" {
+		return false
+	}
+	t, err := time.Parse(time.RFC3339, rotatedAt)
+	if err != nil {
+		return false
+	}
+	return time.Now().After(t.AddDate(0, 0, days))
+}
+
+// returns true if the plan rotates the secret: client_secret is then unknown in the plan (see customizeDiffConnectedAppRotation).
+// the clock isn't checked again at apply time, the plan would no longer match the result if the secret expired in between.
+func isConnectedAppSecretRotationPlanned(d *schema.ResourceData) bool {
+	plan := d.GetRawPlan()
+	if plan.IsNull() || !plan.IsKnown() {
+		return false
+	}
+	return !plan.GetAttr("client_secret").IsKnown()
+}
+
+// regenerates the secret of the connected app on the platform and returns the new secret.
+// the previous secret is revoked right away.
+func regenerateConnectedAppSecret(ctx context.Context, pco *ProviderConfOutput, orgid, connappid string) (string, error) {
+	var res connectedAppSecret
+	authctx := getAccountsAuthCtx(ctx, pco)
+	path := fmt.Sprintf("/organizations/%s/connectedApplications/%s/secret/reset", url.PathEscape(orgid), url.PathEscape(connappid))
+	httpr, err := pco.accountsclient.Execute(authctx, http.MethodPost, path, nil, &res)
+	if err != nil {
+		return "", errors.New(getHttpErrorDetails(httpr, err))
+	}
+	httpr.Body.Close()
+	if res.ClientSecret == "" {
+		return "", fmt.Errorf("the platform didn't return the new secret")
+	}
+	return res.ClientSecret, nil
+}
+
+type connectedAppSecret struct {
+	ClientSecret string `json:"client_secret"`
+}
+
 // Compares 2 scopes lists
 // returns true if they are the same, false otherwise
 func equalsConnectedAppScopes(old, new interface{}) bool {
@@ -618,7 +785,7 @@ func sortScopes(list []interface{}) {
  * Returns authentication context (includes authorization header)
  */
 func getConnectedAppAuthCtx(ctx context.Context, pco *ProviderConfOutput) context.Context {
-	tmp := context.WithValue(ctx, connected_app.ContextAccessToken, pco.access_token)
+	tmp := context.WithValue(ctx, connected_app.ContextAccessToken, pco.tokensource.Token(ctx))
 	return context.WithValue(tmp, connected_app.ContextServerIndex, pco.server_index)
 }
 
@@ -21,6 +21,7 @@ func resourceDLB() *schema.Resource {
 		ReadContext:   resourceDLBRead,
 		UpdateContext: resourceDLBUpdate,
 		DeleteContext: resourceDLBDelete,
+		CustomizeDiff: customizeDiffProviderDefaults,
 		Description: `
 		Creates a ` + "`" + `dedicated load balancer` + "`" + ` instance in your ` + "`" + `vpc` + "`" + `.
 		`,
@@ -39,8 +40,9 @@ func resourceDLB() *schema.Resource {
 			"org_id": {
 				Type:        schema.TypeString,
 				ForceNew:    true,
-				Required:    true,
-				Description: "The organization id where the dlb is defined.",
+				Optional:    true,
+				Computed:    true,
+				Description: "The organization id where the dlb is defined. Defaults to the provider's default_org_id.",
 			},
 			"vpc_id": {
 				Type:        schema.TypeString,
@@ -731,7 +733,7 @@ func compareDLBStates(old, new string) bool {
 
 // Returns authentication context (includes authorization header)
 func getDLBAuthCtx(ctx context.Context, pco *ProviderConfOutput) context.Context {
-	tmp := context.WithValue(ctx, dlb.ContextAccessToken, pco.access_token)
+	tmp := context.WithValue(ctx, dlb.ContextAccessToken, pco.tokensource.Token(ctx))
 	return context.WithValue(tmp, dlb.ContextServerIndex, pco.server_index)
 }
 
@@ -2,7 +2,12 @@ package anypoint
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io"
+	"log"
+	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -18,6 +23,25 @@ func resourceENV() *schema.Resource {
 		ReadContext:   resourceENVRead,
 		UpdateContext: resourceENVUpdate,
 		DeleteContext: resourceENVDelete,
+		CustomizeDiff: func(ctx context.Context, rd *schema.ResourceDiff, i interface{}) error {
+			if err := customizeDiffProviderDefaults(ctx, rd, i); err != nil {
+				return err
+			}
+			pco := i.(ProviderConfOutput)
+			// only the conversion between sandbox and production is supported in place
+			if rd.Id() != "" && rd.HasChange("type") {
+				old, new := rd.GetChange("type")
+				if !isENVTypeChangeInPlace(old.(string), new.(string)) {
+					if err := rd.ForceNew("type"); err != nil {
+						return err
+					}
+				}
+			}
+			if !rd.NewValueKnown("type") {
+				return rd.SetNewComputed("deletion_protection")
+			}
+			return customizeDiffDeletionProtection(rd, &pco, rd.Get("type").(string))
+		},
 		Description: `
 		Creates an ` + "`" + `environement` + "`" + ` for your ` + "`" + `org` + "`" + `.
 		`,
@@ -35,9 +59,10 @@ func resourceENV() *schema.Resource {
 			},
 			"org_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The organization id where the environment is defined.",
+				Description: "The organization id where the environment is defined. Defaults to the provider's default_org_id.",
 			},
 			"name": {
 				Type:        schema.TypeString,
@@ -52,8 +77,7 @@ func resourceENV() *schema.Resource {
 			"type": {
 				Type:             schema.TypeString,
 				Required:         true,
-				ForceNew:         true,
-				Description:      "The type of the environment: sandbox or production",
+				Description:      "The type of the environment: sandbox, design or production. Converting a sandbox environment to production (or the opposite) is done in place, any other type change recreates the environment.",
 				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"sandbox", "design", "production"}, true)),
 			},
 			"client_id": {
@@ -61,6 +85,31 @@ func resourceENV() *schema.Resource {
 				Computed:    true,
 				Description: "The environment client id",
 			},
+			"client_secret": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The environment client secret, used along with the client id to configure the runtime agents.",
+			},
+			"monitoring_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether Anypoint Monitoring is enabled for the applications of the environment. The platform's setting is kept if not set.",
+			},
+			"log_retention_days": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Computed:         true,
+				Description:      "The default number of days the logs of the environment's applications are retained. The platform's setting is kept if not set.",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(1, 365)),
+			},
+			"deletion_protection": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the environment is protected from deletion. While enabled, the environment cannot be destroyed or replaced, it has to be set to false in an apply first. Defaults to true if the environment type is listed in the provider's protected_environment_types, false otherwise.",
+			},
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -94,6 +143,17 @@ func resourceENVCreate(ctx context.Context, d *schema.ResourceData, m interface{
 	}
 	defer httpr.Body.Close()
 	d.SetId(res.GetId())
+	//apply the environment settings if configured
+	if isENVSettingsConfigured(d) {
+		if err := updateENVSettings(ctx, &pco, orgid, d.Id(), d); err != nil {
+			diags := append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to update settings of environment " + d.Id(),
+				Detail:   err.Error(),
+			})
+			return diags
+		}
+	}
 	return resourceENVRead(ctx, d, m)
 }
 
@@ -136,6 +196,18 @@ func resourceENVRead(ctx context.Context, d *schema.ResourceData, m interface{})
 		})
 		return diags
 	}
+	//the client secret and the settings are not available to all users, they are left unchanged if they cannot be read
+	if secret, err := getENVClientSecret(ctx, &pco, orgid, envinstance["client_id"].(string)); err != nil {
+		log.Printf("[WARN] unable to read client secret of environment %s: %s", envid, err)
+	} else {
+		d.Set("client_secret", secret)
+	}
+	if settings, err := getENVSettings(ctx, &pco, orgid, envid); err != nil {
+		log.Printf("[WARN] unable to read settings of environment %s: %s", envid, err)
+	} else {
+		d.Set("monitoring_enabled", settings.MonitoringEnabled)
+		d.Set("log_retention_days", settings.LogRetentionDays)
+	}
 	d.SetId(envid)
 	d.Set("org_id", orgid)
 	return diags
@@ -147,10 +219,11 @@ func resourceENVUpdate(ctx context.Context, d *schema.ResourceData, m interface{
 	envid := d.Id()
 	orgid := d.Get("org_id").(string)
 	authctx := getENVAuthCtx(ctx, &pco)
+	updated := false
 	//check changes
 	if d.HasChanges(getENVCoreAttributes()...) {
 		body := newENVPutBody(d)
-		//request env creation
+		//request env update
 		_, httpr, err := pco.envclient.DefaultApi.OrganizationsOrgIdEnvironmentsEnvironmentIdPut(authctx, orgid, envid).EnvCore(*body).Execute()
 		if err != nil {
 			var details string
@@ -169,14 +242,39 @@ func resourceENVUpdate(ctx context.Context, d *schema.ResourceData, m interface{
 			return diags
 		}
 		defer httpr.Body.Close()
+		if d.HasChange("type") {
+			old, new := d.GetChange("type")
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Environment " + envid + " type changed in place",
+				Detail:   fmt.Sprintf("The environment was converted from %s to %s. Its applications, secret groups and settings are kept, review the policies and entitlements depending on the environment type.", old, new),
+			})
+		}
+		updated = true
+	}
+	if d.HasChanges(getENVSettingsAttributes()...) {
+		if err := updateENVSettings(ctx, &pco, orgid, envid, d); err != nil {
+			diags := append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to update settings of environment " + envid,
+				Detail:   err.Error(),
+			})
+			return diags
+		}
+		updated = true
+	}
+	if updated {
 		d.Set("last_updated", time.Now().Format(time.RFC850))
-		return resourceENVRead(ctx, d, m)
+		return append(diags, resourceENVRead(ctx, d, m)...)
 	}
 	return diags
 }
 
 func resourceENVDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
+	if diags := checkDeletionProtection(d, "Unable to delete environment "+d.Id()); diags != nil {
+		return diags
+	}
 	pco := m.(ProviderConfOutput)
 	envid := d.Id()
 	orgid := d.Get("org_id").(string)
@@ -225,6 +323,9 @@ func newENVPutBody(d *schema.ResourceData) *env.EnvCore {
 	body := env.NewEnvCoreWithDefaults()
 
 	body.SetName(d.Get("name").(string))
+	if d.HasChange("type") {
+		body.SetType(d.Get("type").(string))
+	}
 
 	return body
 }
@@ -233,10 +334,110 @@ func newENVPutBody(d *schema.ResourceData) *env.EnvCore {
  * Returns authentication context (includes authorization header)
  */
 func getENVAuthCtx(ctx context.Context, pco *ProviderConfOutput) context.Context {
-	tmp := context.WithValue(ctx, env.ContextAccessToken, pco.access_token)
+	tmp := context.WithValue(ctx, env.ContextAccessToken, pco.tokensource.Token(ctx))
 	return context.WithValue(tmp, env.ContextServerIndex, pco.server_index)
 }
 
+// returns true if the environment type can be changed in place: between sandbox and production
+func isENVTypeChangeInPlace(old, new string) bool {
+	inplace := []string{"sandbox", "production"}
+	return StringInSlice(inplace, old, true) && StringInSlice(inplace, new, true)
+}
+
+func getENVSettingsAttributes() []string {
+	attributes := [...]string{
+		"monitoring_enabled", "log_retention_days",
+	}
+	return attributes[:]
+}
+
+// returns true if one of the environment settings is set in the configuration
+func isENVSettingsConfigured(d *schema.ResourceData) bool {
+	raw := d.GetRawConfig()
+	if raw.IsNull() {
+		return false
+	}
+	for _, attr := range getENVSettingsAttributes() {
+		if !raw.GetAttr(attr).IsNull() {
+			return true
+		}
+	}
+	return false
+}
+
+type envClientResponse struct {
+	ClientId     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	Name         string `json:"name,omitempty"`
+}
+
+type envSettings struct {
+	MonitoringEnabled bool `json:"monitoringEnabled"`
+	LogRetentionDays  int  `json:"logRetentionDays,omitempty"`
+}
+
+// returns the secret of the environment's client
+func getENVClientSecret(ctx context.Context, pco *ProviderConfOutput, orgid string, clientid string) (string, error) {
+	if clientid == "" {
+		return "", fmt.Errorf("the environment has no client")
+	}
+	authctx := getAccountsAuthCtx(ctx, pco)
+	var res envClientResponse
+	path := fmt.Sprintf("/organizations/%s/clients/%s", url.PathEscape(orgid), url.PathEscape(clientid))
+	httpr, err := pco.accountsclient.Execute(authctx, http.MethodGet, path, nil, &res)
+	if err != nil {
+		return "", errors.New(getHttpErrorDetails(httpr, err))
+	}
+	httpr.Body.Close()
+	return res.ClientSecret, nil
+}
+
+// returns the monitoring settings of the environment
+func getENVSettings(ctx context.Context, pco *ProviderConfOutput, orgid string, envid string) (*envSettings, error) {
+	authctx := getENVSettingsAuthCtx(ctx, pco)
+	var res envSettings
+	httpr, err := pco.envsettingsclient.Execute(authctx, http.MethodGet, getENVSettingsPath(orgid, envid), nil, &res)
+	if err != nil {
+		return nil, errors.New(getHttpErrorDetails(httpr, err))
+	}
+	httpr.Body.Close()
+	return &res, nil
+}
+
+// updates the monitoring settings of the environment with the configured values, the others are kept
+func updateENVSettings(ctx context.Context, pco *ProviderConfOutput, orgid string, envid string, d *schema.ResourceData) error {
+	settings, err := getENVSettings(ctx, pco, orgid, envid)
+	if err != nil {
+		return err
+	}
+	raw := d.GetRawConfig()
+	if !raw.IsNull() && !raw.GetAttr("monitoring_enabled").IsNull() {
+		settings.MonitoringEnabled = d.Get("monitoring_enabled").(bool)
+	}
+	if !raw.IsNull() && !raw.GetAttr("log_retention_days").IsNull() {
+		settings.LogRetentionDays = d.Get("log_retention_days").(int)
+	}
+	authctx := getENVSettingsAuthCtx(ctx, pco)
+	httpr, err := pco.envsettingsclient.Execute(authctx, http.MethodPut, getENVSettingsPath(orgid, envid), settings, nil)
+	if err != nil {
+		return errors.New(getHttpErrorDetails(httpr, err))
+	}
+	httpr.Body.Close()
+	return nil
+}
+
+func getENVSettingsPath(orgid string, envid string) string {
+	return fmt.Sprintf("/organizations/%s/environments/%s/settings", url.PathEscape(orgid), url.PathEscape(envid))
+}
+
+/*
+ * Returns authentication context (includes authorization header)
+ */
+func getENVSettingsAuthCtx(ctx context.Context, pco *ProviderConfOutput) context.Context {
+	tmp := context.WithValue(ctx, RestContextAccessToken, pco.tokensource.Token(ctx))
+	return context.WithValue(tmp, RestContextServerIndex, pco.server_index)
+}
+
 func decomposeEnvId(d *schema.ResourceData) (string, string) {
 	s := DecomposeResourceId(d.Id())
 	return s[0], s[1]
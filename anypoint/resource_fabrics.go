@@ -16,6 +16,7 @@ func resourceFabrics() *schema.Resource {
 		ReadContext:   resourceFabricsRead,
 		UpdateContext: resourceFabricsUpdate,
 		DeleteContext: resourceFabricsDelete,
+		CustomizeDiff: customizeDiffProviderDefaults,
 		Description: `
 		Creates a ` + "`" + `Runtime Fabrics` + "`" + ` instance.
 		`,
@@ -27,9 +28,10 @@ func resourceFabrics() *schema.Resource {
 			},
 			"org_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The organization id where the fabrics is defined.",
+				Description: "The organization id where the fabrics is defined. Defaults to the provider's default_org_id.",
 			},
 			"name": {
 				Type:        schema.TypeString,
@@ -166,6 +168,12 @@ func resourceFabrics() *schema.Resource {
 				Description: "The ingress configurations of this cluster.",
 				Elem:        FabricsIngressDomainsDefinition,
 			},
+			"deletion_protection": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the fabrics is protected from deletion. While enabled, the fabrics cannot be destroyed or replaced, it has to be set to false in an apply first.",
+			},
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -255,6 +263,9 @@ func resourceFabricsUpdate(ctx context.Context, d *schema.ResourceData, m interf
 
 func resourceFabricsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
+	if diags := checkDeletionProtection(d, "Unable to delete fabrics "+d.Id()); diags != nil {
+		return diags
+	}
 	pco := m.(ProviderConfOutput)
 	fabricsid := d.Id()
 	orgid := d.Get("org_id").(string)
@@ -302,6 +313,6 @@ func decomposeFabricsId(d *schema.ResourceData) (string, string) {
  * Returns authentication context (includes authorization header)
  */
 func getFabricsAuthCtx(ctx context.Context, pco *ProviderConfOutput) context.Context {
-	tmp := context.WithValue(ctx, rtf.ContextAccessToken, pco.access_token)
+	tmp := context.WithValue(ctx, rtf.ContextAccessToken, pco.tokensource.Token(ctx))
 	return context.WithValue(tmp, rtf.ContextServerIndex, pco.server_index)
 }
@@ -14,6 +14,7 @@ func resourceFabricsAssociations() *schema.Resource {
 		CreateContext: resourceFabricsAssociationsCreate,
 		ReadContext:   resourceFabricsAssociationsRead,
 		DeleteContext: resourceFabricsAssociationsDelete,
+		CustomizeDiff: customizeDiffProviderDefaults,
 		Description: `
 		Manages ` + "`" + `Runtime Fabrics` + "`" + ` Environment associations.
 		NOTE: The fabrics will be associated with all sandbox environments in every available org when this resource is deleted.
@@ -32,9 +33,10 @@ func resourceFabricsAssociations() *schema.Resource {
 			},
 			"org_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The organization id where the fabrics is hosted.",
+				Description: "The organization id where the fabrics is hosted. Defaults to the provider's default_org_id.",
 			},
 			"fabrics_id": {
 				Type:        schema.TypeString,
@@ -0,0 +1,357 @@
+package anypoint
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type hybridClusterServer struct {
+	ServerId int    `json:"serverId"`
+	ServerIp string `json:"serverIp,omitempty"`
+}
+
+type hybridCluster struct {
+	Id               int            `json:"id"`
+	Name             string         `json:"name"`
+	MulticastEnabled bool           `json:"multicastEnabled"`
+	Status           string         `json:"status,omitempty"`
+	PrimaryNodeId    int            `json:"primaryNodeId,omitempty"`
+	Servers          []hybridServer `json:"servers,omitempty"`
+}
+
+type hybridClusterResponse struct {
+	Data hybridCluster `json:"data"`
+}
+
+func resourceHybridCluster() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceHybridClusterCreate,
+		ReadContext:   resourceHybridClusterRead,
+		UpdateContext: resourceHybridClusterUpdate,
+		DeleteContext: resourceHybridClusterDelete,
+		CustomizeDiff: func(ctx context.Context, rd *schema.ResourceDiff, i interface{}) error {
+			if err := customizeDiffProviderDefaults(ctx, rd, i); err != nil {
+				return err
+			}
+			return validateHybridClusterServers(rd)
+		},
+		Description: `
+		Create and manage a Runtime Manager ` + "`" + `cluster` + "`" + ` of on-premise mule runtimes (hybrid).
+		The servers of a cluster share the state of the applications deployed to it.
+		Cluster nodes discover each other either using multicast or using unicast, in which case the ip address of each server is required.
+		`,
+		Schema: map[string]*schema.Schema{
+			"last_updated": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The last time this resource has been updated locally.",
+			},
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The unique id of this cluster.",
+			},
+			"org_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The organization id where the cluster is defined. Defaults to the provider's default_org_id.",
+			},
+			"env_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The environment id where the cluster is defined. Defaults to the provider's default_env_id or default_env_name.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the cluster.",
+			},
+			"multicast_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the cluster nodes discover each other using multicast. When false (unicast), the server_ip of each server is required.",
+			},
+			"servers": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Description: "The servers of the cluster. A server can only belong to one server group or cluster.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"server_id": {
+							Type:        schema.TypeInt,
+							Required:    true,
+							Description: "The id of the server.",
+						},
+						"server_ip": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							Description:      "The ip address used by the other nodes to reach the server. Required for unicast clusters, not allowed for multicast clusters.",
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IsIPAddress),
+						},
+					},
+				},
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the cluster, depending on the status of its servers.",
+			},
+			"primary_node_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The id of the server acting as the primary node of the cluster.",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceHybridClusterCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid := d.Get("org_id").(string)
+	envid := d.Get("env_id").(string)
+	name := d.Get("name").(string)
+	authctx := getHybridAuthCtx(ctx, &pco, orgid, envid)
+	//prepare body
+	body := map[string]interface{}{
+		"name":             name,
+		"multicastEnabled": d.Get("multicast_enabled").(bool),
+		"servers":          expandHybridClusterServers(d.Get("servers").(*schema.Set).List()),
+	}
+	//perform request
+	var res hybridClusterResponse
+	httpr, err := pco.hybridclient.Execute(authctx, http.MethodPost, "/clusters", body, &res)
+	if err != nil {
+		var details string
+		if httpr != nil && httpr.StatusCode >= 400 {
+			defer httpr.Body.Close()
+			b, _ := io.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to create cluster " + name,
+			Detail:   details,
+		})
+		return diags
+	}
+	defer httpr.Body.Close()
+	d.SetId(strconv.Itoa(res.Data.Id))
+
+	return resourceHybridClusterRead(ctx, d, m)
+}
+
+func resourceHybridClusterRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid := d.Get("org_id").(string)
+	envid := d.Get("env_id").(string)
+	id := d.Id()
+	if isComposedResourceId(id) {
+		orgid, envid, id = decomposeHybridResourceId(d)
+	}
+	authctx := getHybridAuthCtx(ctx, &pco, orgid, envid)
+	//perform request
+	var res hybridClusterResponse
+	httpr, err := pco.hybridclient.Execute(authctx, http.MethodGet, "/clusters/"+id, nil, &res)
+	if err != nil {
+		var details string
+		if httpr != nil && httpr.StatusCode >= 400 {
+			defer httpr.Body.Close()
+			b, _ := io.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to read cluster " + id,
+			Detail:   details,
+		})
+		return diags
+	}
+	defer httpr.Body.Close()
+	//process data
+	cluster := res.Data
+	if err := d.Set("servers", flattenHybridClusterServers(&cluster)); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to set cluster " + id + " attributes",
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	d.Set("name", cluster.Name)
+	d.Set("multicast_enabled", cluster.MulticastEnabled)
+	d.Set("status", cluster.Status)
+	d.Set("primary_node_id", cluster.PrimaryNodeId)
+	d.SetId(id)
+	d.Set("org_id", orgid)
+	d.Set("env_id", envid)
+
+	return diags
+}
+
+func resourceHybridClusterUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid := d.Get("org_id").(string)
+	envid := d.Get("env_id").(string)
+	id := d.Id()
+	authctx := getHybridAuthCtx(ctx, &pco, orgid, envid)
+	if d.HasChanges("name", "multicast_enabled") {
+		body := map[string]interface{}{
+			"name":             d.Get("name").(string),
+			"multicastEnabled": d.Get("multicast_enabled").(bool),
+		}
+		httpr, err := pco.hybridclient.Execute(authctx, http.MethodPatch, "/clusters/"+id, body, nil)
+		if err != nil {
+			var details string
+			if httpr != nil && httpr.StatusCode >= 400 {
+				defer httpr.Body.Close()
+				b, _ := io.ReadAll(httpr.Body)
+				details = string(b)
+			} else {
+				details = err.Error()
+			}
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to update cluster " + id,
+				Detail:   details,
+			})
+			return diags
+		}
+		defer httpr.Body.Close()
+	}
+	if d.HasChange("servers") {
+		old, new := d.GetChange("servers")
+		// a server whose ip changes is removed then added again
+		toremove := old.(*schema.Set).Difference(new.(*schema.Set)).List()
+		toadd := new.(*schema.Set).Difference(old.(*schema.Set)).List()
+		for _, item := range toremove {
+			serverid := item.(map[string]interface{})["server_id"].(int)
+			if httpr, err := pco.hybridclient.Execute(authctx, http.MethodDelete, getHybridClusterServerPath(id, serverid), nil, nil); err != nil {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Unable to remove server " + strconv.Itoa(serverid) + " from cluster " + id,
+					Detail:   getHttpErrorDetails(httpr, err),
+				})
+				return diags
+			}
+		}
+		for _, server := range expandHybridClusterServers(toadd) {
+			if httpr, err := pco.hybridclient.Execute(authctx, http.MethodPost, "/clusters/"+id+"/servers", server, nil); err != nil {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Unable to add server " + strconv.Itoa(server.ServerId) + " to cluster " + id,
+					Detail:   getHttpErrorDetails(httpr, err),
+				})
+				return diags
+			}
+		}
+	}
+	d.Set("last_updated", time.Now().Format(time.RFC850))
+
+	return resourceHybridClusterRead(ctx, d, m)
+}
+
+func resourceHybridClusterDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid := d.Get("org_id").(string)
+	envid := d.Get("env_id").(string)
+	id := d.Id()
+	authctx := getHybridAuthCtx(ctx, &pco, orgid, envid)
+	httpr, err := pco.hybridclient.Execute(authctx, http.MethodDelete, "/clusters/"+id, nil, nil)
+	if err != nil {
+		var details string
+		if httpr != nil && httpr.StatusCode >= 400 {
+			defer httpr.Body.Close()
+			b, _ := io.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to delete cluster " + id,
+			Detail:   details,
+		})
+		return diags
+	}
+	defer httpr.Body.Close()
+	// d.SetId("") is automatically called assuming delete returns no errors, but
+	// it is added here for explicitness.
+	d.SetId("")
+	return diags
+}
+
+// unicast clusters require the ip address of each server, multicast clusters ignore it
+func validateHybridClusterServers(rd *schema.ResourceDiff) error {
+	if !rd.NewValueKnown("multicast_enabled") || !rd.NewValueKnown("servers") {
+		return nil
+	}
+	multicast := rd.Get("multicast_enabled").(bool)
+	for _, item := range rd.Get("servers").(*schema.Set).List() {
+		server := item.(map[string]interface{})
+		ip := server["server_ip"].(string)
+		if !multicast && ip == "" {
+			return fmt.Errorf("server_ip of server %d is required when multicast_enabled is false (unicast cluster)", server["server_id"].(int))
+		}
+		if multicast && ip != "" {
+			return fmt.Errorf("server_ip of server %d is not allowed when multicast_enabled is true (multicast cluster)", server["server_id"].(int))
+		}
+	}
+	return nil
+}
+
+func expandHybridClusterServers(items []interface{}) []hybridClusterServer {
+	servers := make([]hybridClusterServer, len(items))
+	for i, item := range items {
+		server := item.(map[string]interface{})
+		servers[i] = hybridClusterServer{
+			ServerId: server["server_id"].(int),
+			ServerIp: server["server_ip"].(string),
+		}
+	}
+	return servers
+}
+
+func flattenHybridClusterServers(cluster *hybridCluster) []interface{} {
+	servers := make([]interface{}, len(cluster.Servers))
+	for i, server := range cluster.Servers {
+		item := make(map[string]interface{})
+		item["server_id"] = server.Id
+		// the ip address is only relevant for unicast clusters
+		if !cluster.MulticastEnabled {
+			item["server_ip"] = server.ServerIp
+		} else {
+			item["server_ip"] = ""
+		}
+		servers[i] = item
+	}
+	return servers
+}
+
+func getHybridClusterServerPath(clusterid string, serverid int) string {
+	return fmt.Sprintf("/clusters/%s/servers/%d", clusterid, serverid)
+}
@@ -0,0 +1,500 @@
+package anypoint
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	HYBRID_APP_STATUS_STARTED           = "STARTED"
+	HYBRID_APP_STATUS_STOPPED           = "STOPPED"
+	HYBRID_APP_STATUS_DEPLOYMENT_FAILED = "DEPLOYMENT_FAILED"
+	HYBRID_APP_DEFAULT_TIMEOUT          = 10 * time.Minute
+	HYBRID_APP_POLL_INTERVAL            = 5 * time.Second
+	// the agent's service holding the application properties
+	HYBRID_APP_PROPERTIES_SERVICE = "mule.agent.application.properties.service"
+)
+
+type hybridApplicationArtifact struct {
+	Name         string `json:"name"`
+	FileName     string `json:"fileName,omitempty"`
+	FileChecksum string `json:"fileChecksum,omitempty"`
+}
+
+// the status of the application reported by the runtime of a server
+type hybridServerArtifact struct {
+	ServerId           int    `json:"serverId"`
+	LastReportedStatus string `json:"lastReportedStatus,omitempty"`
+	TimeUpdated        int64  `json:"timeUpdated,omitempty"`
+}
+
+type hybridApplication struct {
+	Id                 int                       `json:"id"`
+	TimeUpdated        int64                     `json:"timeUpdated,omitempty"`
+	Artifact           hybridApplicationArtifact `json:"artifact"`
+	DesiredStatus      string                    `json:"desiredStatus,omitempty"`
+	LastReportedStatus string                    `json:"lastReportedStatus,omitempty"`
+	Target             *hybridRef                `json:"target,omitempty"`
+	ServerArtifacts    []hybridServerArtifact    `json:"serverArtifacts,omitempty"`
+}
+
+type hybridApplicationResponse struct {
+	Data hybridApplication `json:"data"`
+}
+
+func resourceHybridDeployment() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceHybridDeploymentCreate,
+		ReadContext:   resourceHybridDeploymentRead,
+		UpdateContext: resourceHybridDeploymentUpdate,
+		DeleteContext: resourceHybridDeploymentDelete,
+		CustomizeDiff: func(ctx context.Context, rd *schema.ResourceDiff, i interface{}) error {
+			if err := customizeDiffProviderDefaults(ctx, rd, i); err != nil {
+				return err
+			}
+			return customizeDiffArtifactHash(rd)
+		},
+		Description: `
+		Deploys a mule application to on-premise mule runtimes managed by Runtime Manager (hybrid).
+		The target is either a server, a server group or a cluster.
+		The application artifact is either an asset published in Exchange or a local jar file uploaded at deployment time.
+		Create and update operations wait until the application is started.
+		`,
+		Schema: map[string]*schema.Schema{
+			"last_updated": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The last time this resource has been updated locally.",
+			},
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The unique id of this deployment.",
+			},
+			"org_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The organization id where the application is deployed. Defaults to the provider's default_org_id.",
+			},
+			"env_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The environment id where the application is deployed. Defaults to the provider's default_env_id or default_env_name.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the application.",
+			},
+			"target_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The id of the server, server group or cluster the application is deployed to.",
+			},
+			"exchange": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: []string{"exchange", "artifact_path"},
+				Description:  "The Exchange asset of the application.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"group_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+							Description: "The group id of the asset. Defaults to the org_id.",
+						},
+						"artifact_id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The artifact id of the asset.",
+						},
+						"version": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The version of the asset.",
+						},
+					},
+				},
+			},
+			"artifact_path": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"exchange", "artifact_path"},
+				Description:  "The path of a local application jar file. The application is redeployed when the content of the file changes.",
+			},
+			"artifact_hash": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The sha256 checksum of the local application jar file given by artifact_path.",
+			},
+			"properties": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "The mule application properties.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The last status reported by the runtimes for the application, i.e. STARTED, DEPLOYMENT_FAILED.",
+			},
+			"desired_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The desired status of the application.",
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(HYBRID_APP_DEFAULT_TIMEOUT),
+			Update: schema.DefaultTimeout(HYBRID_APP_DEFAULT_TIMEOUT),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceHybridDeploymentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid := d.Get("org_id").(string)
+	envid := d.Get("env_id").(string)
+	name := d.Get("name").(string)
+	authctx := getHybridAuthCtx(ctx, &pco, orgid, envid)
+	//perform request
+	var res hybridApplicationResponse
+	var httpr *http.Response
+	var err error
+	if path, ok := d.GetOk("artifact_path"); ok {
+		fields := map[string]string{
+			"artifactName": name,
+			"targetId":     strconv.Itoa(d.Get("target_id").(int)),
+		}
+		httpr, err = sendHybridArtifact(authctx, &pco, http.MethodPost, "/applications", path.(string), fields, d, &res)
+	} else {
+		body := newHybridDeploymentBody(d)
+		body["artifactName"] = name
+		body["targetId"] = d.Get("target_id").(int)
+		httpr, err = pco.hybridclient.Execute(authctx, http.MethodPost, "/applications", body, &res)
+	}
+	if err != nil {
+		var details string
+		if httpr != nil && httpr.StatusCode >= 400 {
+			defer httpr.Body.Close()
+			b, _ := io.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to deploy application " + name,
+			Detail:   details,
+		})
+		return diags
+	}
+	defer httpr.Body.Close()
+	id := strconv.Itoa(res.Data.Id)
+	d.SetId(id)
+	//wait for the deployment
+	if _, err := waitHybridDeploymentStarted(ctx, &pco, orgid, envid, id, 0, d.Timeout(schema.TimeoutCreate)); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to deploy application " + name,
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+
+	return resourceHybridDeploymentRead(ctx, d, m)
+}
+
+func resourceHybridDeploymentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid := d.Get("org_id").(string)
+	envid := d.Get("env_id").(string)
+	id := d.Id()
+	if isComposedResourceId(id) {
+		orgid, envid, id = decomposeHybridResourceId(d)
+	}
+	app, err := getHybridApplication(ctx, &pco, orgid, envid, id)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to read application " + id,
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	//process data
+	d.Set("name", app.Artifact.Name)
+	if app.Target != nil {
+		d.Set("target_id", app.Target.Id)
+	}
+	d.Set("status", app.LastReportedStatus)
+	d.Set("desired_status", app.DesiredStatus)
+	if val, ok := d.GetOk("exchange"); ok {
+		exchange := val.([]interface{})
+		if len(exchange) > 0 && exchange[0] != nil {
+			item := exchange[0].(map[string]interface{})
+			if item["group_id"] == "" {
+				item["group_id"] = orgid
+				d.Set("exchange", []interface{}{item})
+			}
+		}
+	}
+	d.SetId(id)
+	d.Set("org_id", orgid)
+	d.Set("env_id", envid)
+
+	return diags
+}
+
+func resourceHybridDeploymentUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid := d.Get("org_id").(string)
+	envid := d.Get("env_id").(string)
+	name := d.Get("name").(string)
+	id := d.Id()
+	if !d.HasChanges("exchange", "artifact_path", "artifact_hash", "properties") {
+		return resourceHybridDeploymentRead(ctx, d, m)
+	}
+	authctx := getHybridAuthCtx(ctx, &pco, orgid, envid)
+	//perform request
+	var res hybridApplicationResponse
+	var httpr *http.Response
+	var err error
+	if path, ok := d.GetOk("artifact_path"); ok && d.HasChanges("artifact_path", "artifact_hash") {
+		httpr, err = sendHybridArtifact(authctx, &pco, http.MethodPatch, "/applications/"+id, path.(string), nil, d, &res)
+	} else {
+		httpr, err = pco.hybridclient.Execute(authctx, http.MethodPatch, "/applications/"+id, newHybridDeploymentBody(d), &res)
+	}
+	if err != nil {
+		var details string
+		if httpr != nil && httpr.StatusCode >= 400 {
+			defer httpr.Body.Close()
+			b, _ := io.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to update application " + name,
+			Detail:   details,
+		})
+		return diags
+	}
+	defer httpr.Body.Close()
+	//wait for the redeployment, the time of the update tells the reports of the redeployment from the previous ones
+	redeployed := res.Data.TimeUpdated
+	if redeployed == 0 {
+		app, err := getHybridApplication(ctx, &pco, orgid, envid, id)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to update application " + name,
+				Detail:   err.Error(),
+			})
+			return diags
+		}
+		redeployed = app.TimeUpdated
+	}
+	if _, err := waitHybridDeploymentStarted(ctx, &pco, orgid, envid, id, redeployed, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to update application " + name,
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	d.Set("last_updated", time.Now().Format(time.RFC850))
+
+	return resourceHybridDeploymentRead(ctx, d, m)
+}
+
+func resourceHybridDeploymentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid := d.Get("org_id").(string)
+	envid := d.Get("env_id").(string)
+	id := d.Id()
+	authctx := getHybridAuthCtx(ctx, &pco, orgid, envid)
+	httpr, err := pco.hybridclient.Execute(authctx, http.MethodDelete, "/applications/"+id, nil, nil)
+	if err != nil {
+		var details string
+		if httpr != nil && httpr.StatusCode >= 400 {
+			defer httpr.Body.Close()
+			b, _ := io.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to delete application " + id,
+			Detail:   details,
+		})
+		return diags
+	}
+	defer httpr.Body.Close()
+	// d.SetId("") is automatically called assuming delete returns no errors, but
+	// it is added here for explicitness.
+	d.SetId("")
+	return diags
+}
+
+// the json body of a deployment of an exchange asset (or of a properties update)
+func newHybridDeploymentBody(d *schema.ResourceData) map[string]interface{} {
+	body := make(map[string]interface{})
+	if val, ok := d.GetOk("exchange"); ok {
+		exchange := val.([]interface{})
+		if len(exchange) > 0 && exchange[0] != nil {
+			item := exchange[0].(map[string]interface{})
+			groupid := item["group_id"].(string)
+			if groupid == "" {
+				groupid = d.Get("org_id").(string)
+			}
+			body["applicationSource"] = map[string]interface{}{
+				"source":         "EXCHANGE",
+				"groupId":        groupid,
+				"artifactId":     item["artifact_id"].(string),
+				"version":        item["version"].(string),
+				"organizationId": d.Get("org_id").(string),
+			}
+		}
+	}
+	body["configuration"] = newHybridDeploymentConfiguration(d)
+	return body
+}
+
+func newHybridDeploymentConfiguration(d *schema.ResourceData) map[string]interface{} {
+	properties := make(map[string]interface{})
+	for key, val := range d.Get("properties").(map[string]interface{}) {
+		properties[key] = val
+	}
+	return map[string]interface{}{
+		HYBRID_APP_PROPERTIES_SERVICE: map[string]interface{}{
+			"applicationName": d.Get("name").(string),
+			"properties":      properties,
+		},
+	}
+}
+
+// uploads the local artifact found at the given path as a multipart form along with the given fields and the application configuration
+func sendHybridArtifact(ctx context.Context, pco *ProviderConfOutput, method string, path string, artifact string, fields map[string]string, d *schema.ResourceData, result interface{}) (*http.Response, error) {
+	f, err := openArtifactFile(artifact)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	for key, val := range fields {
+		if err := w.WriteField(key, val); err != nil {
+			return nil, err
+		}
+	}
+	cfg, err := json.Marshal(newHybridDeploymentConfiguration(d))
+	if err != nil {
+		return nil, err
+	}
+	if err := w.WriteField("configuration", string(cfg)); err != nil {
+		return nil, err
+	}
+	part, err := w.CreateFormFile("file", filepath.Base(artifact))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(part, f); err != nil {
+		return nil, fmt.Errorf("unable to read artifact %s: %s", artifact, err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return pco.hybridclient.ExecuteRaw(ctx, method, path, &buf, w.FormDataContentType(), result)
+}
+
+// returns true if the runtimes of every server of the application have reported its status since the given time (epoch millis)
+func isHybridDeploymentReportedSince(app *hybridApplication, since int64) bool {
+	if since == 0 {
+		return true
+	}
+	if len(app.ServerArtifacts) == 0 {
+		return false
+	}
+	for _, artifact := range app.ServerArtifacts {
+		if artifact.TimeUpdated < since {
+			return false
+		}
+	}
+	return true
+}
+
+func getHybridApplication(ctx context.Context, pco *ProviderConfOutput, orgid, envid, id string) (*hybridApplication, error) {
+	authctx := getHybridAuthCtx(ctx, pco, orgid, envid)
+	var res hybridApplicationResponse
+	httpr, err := pco.hybridclient.Execute(authctx, http.MethodGet, "/applications/"+id, nil, &res)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get application %s. %s", id, getHttpErrorDetails(httpr, err))
+	}
+	httpr.Body.Close()
+	return &res.Data, nil
+}
+
+// waits until the runtimes report the application as started, fails if the deployment failed.
+// redeployed is the time (epoch millis) of a redeployment, 0 for a new deployment: the reported status is only trusted
+// once the runtimes of every server have reported the application since then, the status of the previous deployment
+// can't be taken for the result of the redeployment even if the application went through the same statuses.
+func waitHybridDeploymentStarted(ctx context.Context, pco *ProviderConfOutput, orgid, envid, id string, redeployed int64, timeout time.Duration) (*hybridApplication, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		app, err := getHybridApplication(ctx, pco, orgid, envid, id)
+		if err != nil {
+			return nil, err
+		}
+		reported := isHybridDeploymentReportedSince(app, redeployed)
+		if reported {
+			switch app.LastReportedStatus {
+			case HYBRID_APP_STATUS_STARTED:
+				return app, nil
+			case HYBRID_APP_STATUS_DEPLOYMENT_FAILED:
+				return nil, fmt.Errorf("deployment of application %s failed", app.Artifact.Name)
+			}
+		}
+		if time.Now().After(deadline) {
+			if !reported {
+				return nil, fmt.Errorf("timeout while waiting for application %s to be redeployed, the runtimes haven't reported the redeployment, last reported status is %s", app.Artifact.Name, app.LastReportedStatus)
+			}
+			return nil, fmt.Errorf("timeout while waiting for application %s to start, current status is %s", app.Artifact.Name, app.LastReportedStatus)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(HYBRID_APP_POLL_INTERVAL):
+		}
+	}
+}
@@ -0,0 +1,288 @@
+package anypoint
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type hybridServerGroup struct {
+	Id      int            `json:"id"`
+	Name    string         `json:"name"`
+	Status  string         `json:"status,omitempty"`
+	Servers []hybridServer `json:"servers,omitempty"`
+}
+
+type hybridServerGroupResponse struct {
+	Data hybridServerGroup `json:"data"`
+}
+
+func resourceHybridServerGroup() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceHybridServerGroupCreate,
+		ReadContext:   resourceHybridServerGroupRead,
+		UpdateContext: resourceHybridServerGroupUpdate,
+		DeleteContext: resourceHybridServerGroupDelete,
+		CustomizeDiff: customizeDiffProviderDefaults,
+		Description: `
+		Create and manage a Runtime Manager ` + "`" + `server group` + "`" + ` of on-premise mule runtimes (hybrid).
+		Applications deployed to a server group are deployed to all of its servers, which act independently from each other.
+		The servers have to be registered beforehand, see resource anypoint_hybrid_server_registration_token.
+		`,
+		Schema: map[string]*schema.Schema{
+			"last_updated": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The last time this resource has been updated locally.",
+			},
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The unique id of this server group.",
+			},
+			"org_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The organization id where the server group is defined. Defaults to the provider's default_org_id.",
+			},
+			"env_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The environment id where the server group is defined. Defaults to the provider's default_env_id or default_env_name.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the server group.",
+			},
+			"server_ids": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Description: "The ids of the servers of the group. A server can only belong to one server group or cluster.",
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the server group, depending on the status of its servers.",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceHybridServerGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid := d.Get("org_id").(string)
+	envid := d.Get("env_id").(string)
+	name := d.Get("name").(string)
+	authctx := getHybridAuthCtx(ctx, &pco, orgid, envid)
+	//prepare body
+	body := map[string]interface{}{
+		"name":      name,
+		"serverIds": d.Get("server_ids").(*schema.Set).List(),
+	}
+	//perform request
+	var res hybridServerGroupResponse
+	httpr, err := pco.hybridclient.Execute(authctx, http.MethodPost, "/serverGroups", body, &res)
+	if err != nil {
+		var details string
+		if httpr != nil && httpr.StatusCode >= 400 {
+			defer httpr.Body.Close()
+			b, _ := io.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to create server group " + name,
+			Detail:   details,
+		})
+		return diags
+	}
+	defer httpr.Body.Close()
+	d.SetId(strconv.Itoa(res.Data.Id))
+
+	return resourceHybridServerGroupRead(ctx, d, m)
+}
+
+func resourceHybridServerGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid := d.Get("org_id").(string)
+	envid := d.Get("env_id").(string)
+	id := d.Id()
+	if isComposedResourceId(id) {
+		orgid, envid, id = decomposeHybridResourceId(d)
+	}
+	authctx := getHybridAuthCtx(ctx, &pco, orgid, envid)
+	//perform request
+	var res hybridServerGroupResponse
+	httpr, err := pco.hybridclient.Execute(authctx, http.MethodGet, "/serverGroups/"+id, nil, &res)
+	if err != nil {
+		var details string
+		if httpr != nil && httpr.StatusCode >= 400 {
+			defer httpr.Body.Close()
+			b, _ := io.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to read server group " + id,
+			Detail:   details,
+		})
+		return diags
+	}
+	defer httpr.Body.Close()
+	//process data
+	group := res.Data
+	serverids := make([]int, len(group.Servers))
+	for i, server := range group.Servers {
+		serverids[i] = server.Id
+	}
+	if err := d.Set("name", group.Name); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to set server group " + id + " attributes",
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	if err := d.Set("server_ids", serverids); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to set server group " + id + " attributes",
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	d.Set("status", group.Status)
+	d.SetId(id)
+	d.Set("org_id", orgid)
+	d.Set("env_id", envid)
+
+	return diags
+}
+
+func resourceHybridServerGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid := d.Get("org_id").(string)
+	envid := d.Get("env_id").(string)
+	id := d.Id()
+	authctx := getHybridAuthCtx(ctx, &pco, orgid, envid)
+	if d.HasChange("name") {
+		body := map[string]interface{}{
+			"name": d.Get("name").(string),
+		}
+		httpr, err := pco.hybridclient.Execute(authctx, http.MethodPatch, "/serverGroups/"+id, body, nil)
+		if err != nil {
+			var details string
+			if httpr != nil && httpr.StatusCode >= 400 {
+				defer httpr.Body.Close()
+				b, _ := io.ReadAll(httpr.Body)
+				details = string(b)
+			} else {
+				details = err.Error()
+			}
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to update server group " + id,
+				Detail:   details,
+			})
+			return diags
+		}
+		defer httpr.Body.Close()
+	}
+	if d.HasChange("server_ids") {
+		old, new := d.GetChange("server_ids")
+		toadd := new.(*schema.Set).Difference(old.(*schema.Set)).List()
+		toremove := old.(*schema.Set).Difference(new.(*schema.Set)).List()
+		// servers are added first so the group is never left empty
+		for _, serverid := range toadd {
+			path := getHybridServerGroupServerPath(id, serverid.(int))
+			if httpr, err := pco.hybridclient.Execute(authctx, http.MethodPost, path, nil, nil); err != nil {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Unable to add server " + strconv.Itoa(serverid.(int)) + " to server group " + id,
+					Detail:   getHttpErrorDetails(httpr, err),
+				})
+				return diags
+			}
+		}
+		for _, serverid := range toremove {
+			path := getHybridServerGroupServerPath(id, serverid.(int))
+			if httpr, err := pco.hybridclient.Execute(authctx, http.MethodDelete, path, nil, nil); err != nil {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Unable to remove server " + strconv.Itoa(serverid.(int)) + " from server group " + id,
+					Detail:   getHttpErrorDetails(httpr, err),
+				})
+				return diags
+			}
+		}
+	}
+	d.Set("last_updated", time.Now().Format(time.RFC850))
+
+	return resourceHybridServerGroupRead(ctx, d, m)
+}
+
+func resourceHybridServerGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid := d.Get("org_id").(string)
+	envid := d.Get("env_id").(string)
+	id := d.Id()
+	authctx := getHybridAuthCtx(ctx, &pco, orgid, envid)
+	httpr, err := pco.hybridclient.Execute(authctx, http.MethodDelete, "/serverGroups/"+id, nil, nil)
+	if err != nil {
+		var details string
+		if httpr != nil && httpr.StatusCode >= 400 {
+			defer httpr.Body.Close()
+			b, _ := io.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to delete server group " + id,
+			Detail:   details,
+		})
+		return diags
+	}
+	defer httpr.Body.Close()
+	// d.SetId("") is automatically called assuming delete returns no errors, but
+	// it is added here for explicitness.
+	d.SetId("")
+	return diags
+}
+
+func getHybridServerGroupServerPath(groupid string, serverid int) string {
+	return fmt.Sprintf("/serverGroups/%s/servers/%d", groupid, serverid)
+}
+
+// decomposes the composed id {org_id}/{env_id}/{id} used to import the hybrid resources
+func decomposeHybridResourceId(d *schema.ResourceData) (string, string, string) {
+	s := DecomposeResourceId(d.Id())
+	return s[0], s[1], s[2]
+}
@@ -0,0 +1,117 @@
+package anypoint
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type hybridRegistrationTokenResponse struct {
+	Data string `json:"data"`
+}
+
+func resourceHybridServerRegistrationToken() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceHybridServerRegistrationTokenCreate,
+		ReadContext:   resourceHybridServerRegistrationTokenRead,
+		DeleteContext: resourceHybridServerRegistrationTokenDelete,
+		CustomizeDiff: customizeDiffProviderDefaults,
+		Description: `
+		Obtains a token used to register on-premise mule runtimes (servers) in Runtime Manager for a given organization and environment.
+		The token is passed to the runtime manager agent: ` + "`" + `./amc_setup -H <token> <server_name>` + "`" + `.
+		Anypoint does not allow the revocation of registration tokens, destroying this resource only removes it from the state.
+		Use the ` + "`" + `triggers` + "`" + ` attribute to obtain a new token.
+		`,
+		Schema: map[string]*schema.Schema{
+			"last_updated": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The last time this resource has been updated locally.",
+			},
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The unique id of this resource, composed of the org_id and env_id.",
+			},
+			"org_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The organization id where the servers are registered. Defaults to the provider's default_org_id.",
+			},
+			"env_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The environment id where the servers are registered. Defaults to the provider's default_env_id or default_env_name.",
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Arbitrary map of values that, when changed, obtains a new registration token.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"token": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The server registration token.",
+			},
+		},
+	}
+}
+
+func resourceHybridServerRegistrationTokenCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid := d.Get("org_id").(string)
+	envid := d.Get("env_id").(string)
+	authctx := getHybridAuthCtx(ctx, &pco, orgid, envid)
+	//perform request
+	var res hybridRegistrationTokenResponse
+	httpr, err := pco.hybridclient.Execute(authctx, http.MethodGet, "/servers/registrationToken", nil, &res)
+	if err != nil {
+		var details string
+		if httpr != nil && httpr.StatusCode >= 400 {
+			defer httpr.Body.Close()
+			b, _ := io.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to get server registration token for env " + envid,
+			Detail:   details,
+		})
+		return diags
+	}
+	defer httpr.Body.Close()
+	d.Set("token", res.Data)
+	d.Set("last_updated", time.Now().Format(time.RFC850))
+	d.SetId(ComposeResourceId([]string{orgid, envid}))
+
+	return diags
+}
+
+// the registration token can't be read again, the value in the state is kept as is
+func resourceHybridServerRegistrationTokenRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return nil
+}
+
+func resourceHybridServerRegistrationTokenDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	// d.SetId("") is automatically called assuming delete returns no errors, but
+	// it is added here for explicitness.
+	d.SetId("")
+	return diags
+}
@@ -2,14 +2,21 @@ package anypoint
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"io"
+	"net/http"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	idp "github.com/mulesoft-anypoint/anypoint-client-go/idp"
 )
 
+const OIDC_DISCOVERY_PATH = "/.well-known/openid-configuration"
+
 func resourceOIDC() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceOIDCCreate,
@@ -18,6 +25,7 @@ func resourceOIDC() *schema.Resource {
 		DeleteContext: resourceOIDCDelete,
 		Description: `
 		Creates an ` + "`" + `identity provider` + "`" + ` OIDC type configuration in your account.
+		The issuer and the provider's endpoints can be read from the provider's discovery document using ` + "`" + `discovery_url` + "`" + `.
 		`,
 		Schema: map[string]*schema.Schema{
 			"last_updated": {
@@ -33,8 +41,9 @@ func resourceOIDC() *schema.Resource {
 			},
 			"org_id": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The master organization id where the idp is defined.",
+				Optional:    true,
+				Computed:    true,
+				Description: "The master organization id where the idp is defined. Defaults to the provider's default_org_id.",
 			},
 			"provider_id": {
 				Type:        schema.TypeString,
@@ -51,16 +60,23 @@ func resourceOIDC() *schema.Resource {
 				Computed:    true,
 				Description: "The type of the identity provider, contains description and the name of the type of the provider (saml or oidc)",
 			},
+			"discovery_url": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsURLWithHTTPorHTTPS),
+				Description:      "The issuer url or the discovery document url (" + OIDC_DISCOVERY_PATH + ") of the openid-connect provider. When set, the discovery document is fetched at plan time and the issuer, token, userinfo, authorization and registration urls are read from it. A warning is raised when the published endpoints drift from the ones stored in anypoint.",
+			},
 			"oidc_provider": {
 				Type:        schema.TypeSet,
 				Description: "The description of provider specific for OIDC types",
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"token_url": {
 							Type:        schema.TypeString,
-							Required:    true,
-							Description: "The token url of the openid-connect provider",
+							Optional:    true,
+							Description: "The token url of the openid-connect provider. Required unless discovery_url is provided.",
 						},
 						"redirect_url": {
 							Type:        schema.TypeString,
@@ -69,13 +85,13 @@ func resourceOIDC() *schema.Resource {
 						},
 						"userinfo_url": {
 							Type:        schema.TypeString,
-							Required:    true,
-							Description: "The userinfo url of the openid-connect provider",
+							Optional:    true,
+							Description: "The userinfo url of the openid-connect provider. Required unless discovery_url is provided.",
 						},
 						"authorize_url": {
 							Type:        schema.TypeString,
-							Required:    true,
-							Description: "The authorization url of the openid-connect provider",
+							Optional:    true,
+							Description: "The authorization url of the openid-connect provider. Required unless discovery_url is provided.",
 						},
 						"client_registration_url": {
 							Type:        schema.TypeString,
@@ -103,8 +119,8 @@ func resourceOIDC() *schema.Resource {
 						},
 						"issuer": {
 							Type:        schema.TypeString,
-							Required:    true,
-							Description: "The provider token issuer url",
+							Optional:    true,
+							Description: "The provider token issuer url. Required unless discovery_url is provided.",
 						},
 						"group_scope": {
 							Type:        schema.TypeString,
@@ -131,6 +147,12 @@ func resourceOIDC() *schema.Resource {
 				Description: "The provider's sign out url, only available for SAML",
 			},
 		},
+		CustomizeDiff: func(ctx context.Context, rd *schema.ResourceDiff, i interface{}) error {
+			if err := customizeDiffProviderDefaults(ctx, rd, i); err != nil {
+				return err
+			}
+			return loadOIDCDiscoveryToDiff(ctx, rd)
+		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -210,6 +232,10 @@ func resourceOIDCRead(ctx context.Context, d *schema.ResourceData, m interface{}
 		})
 		return diags
 	}
+	//detect drifts between the discovery document and anypoint
+	if discovery_url, ok := d.GetOk("discovery_url"); ok {
+		diags = append(diags, checkOIDCDiscoveryDrift(ctx, discovery_url.(string), idpinstance)...)
+	}
 	d.SetId(idpid)
 	d.Set("org_id", orgid)
 	return diags
@@ -428,7 +454,7 @@ func newOIDCPatchBody(d *schema.ResourceData) (*idp.IdpPatchBody, diag.Diagnosti
 }
 
 func getIDPAuthCtx(ctx context.Context, pco *ProviderConfOutput) context.Context {
-	tmp := context.WithValue(ctx, idp.ContextAccessToken, pco.access_token)
+	tmp := context.WithValue(ctx, idp.ContextAccessToken, pco.tokensource.Token(ctx))
 	return context.WithValue(tmp, idp.ContextServerIndex, pco.server_index)
 }
 
@@ -436,3 +462,120 @@ func decomposeOIDCId(d *schema.ResourceData) (string, string) {
 	s := DecomposeResourceId(d.Id())
 	return s[0], s[1]
 }
+
+/*
+ * OpenID Connect discovery document support
+ */
+
+type oidcDiscoveryDocument struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserinfoEndpoint      string `json:"userinfo_endpoint"`
+	RegistrationEndpoint  string `json:"registration_endpoint"`
+}
+
+// populates the oidc_provider block from the discovery document (if provided) and validates the input
+func loadOIDCDiscoveryToDiff(ctx context.Context, rd *schema.ResourceDiff) error {
+	if !rd.NewValueKnown("discovery_url") || !rd.NewValueKnown("oidc_provider") {
+		return nil
+	}
+	oidc := make(map[string]interface{})
+	if list := rd.Get("oidc_provider").(*schema.Set).List(); len(list) > 0 {
+		oidc = list[0].(map[string]interface{})
+	}
+	discovery_url := rd.Get("discovery_url").(string)
+	if discovery_url == "" {
+		for _, attr := range []string{"issuer", "token_url", "userinfo_url", "authorize_url"} {
+			if val, ok := oidc[attr]; !ok || val.(string) == "" {
+				return fmt.Errorf("the oidc_provider block's %s is required when no discovery_url is provided", attr)
+			}
+		}
+		return nil
+	}
+	doc, err := readOIDCDiscoveryDocument(ctx, discovery_url)
+	if err != nil {
+		return err
+	}
+	oidc["issuer"] = doc.Issuer
+	oidc["token_url"] = doc.TokenEndpoint
+	oidc["userinfo_url"] = doc.UserinfoEndpoint
+	oidc["authorize_url"] = doc.AuthorizationEndpoint
+	// dynamic registration is only used if no client credentials are provided
+	if val, ok := oidc["client_registration_url"]; !ok || val.(string) == "" {
+		if val, ok := oidc["client_credentials_id"]; !ok || val.(string) == "" {
+			oidc["client_registration_url"] = doc.RegistrationEndpoint
+		}
+	}
+	return rd.SetNew("oidc_provider", []interface{}{oidc})
+}
+
+// raises a warning for each endpoint published by the provider that differs from the one stored in anypoint
+func checkOIDCDiscoveryDrift(ctx context.Context, discovery_url string, idpinstance map[string]interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	doc, err := readOIDCDiscoveryDocument(ctx, discovery_url)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Unable to check OIDC provider endpoints drift",
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	list, ok := idpinstance["oidc_provider"].([]interface{})
+	if !ok || len(list) == 0 {
+		return diags
+	}
+	stored := list[0].(map[string]interface{})
+	published := map[string]string{
+		"issuer":        doc.Issuer,
+		"token_url":     doc.TokenEndpoint,
+		"userinfo_url":  doc.UserinfoEndpoint,
+		"authorize_url": doc.AuthorizationEndpoint,
+	}
+	for _, attr := range []string{"issuer", "token_url", "userinfo_url", "authorize_url"} {
+		if val, ok := stored[attr]; ok && val.(string) != published[attr] {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "OIDC provider " + attr + " drifted from the discovery document",
+				Detail:   fmt.Sprintf("anypoint stores %q while the discovery document at %s publishes %q", val, discovery_url, published[attr]),
+			})
+		}
+	}
+	return diags
+}
+
+// fetches and validates the discovery document. The given url is either the issuer or the discovery document url.
+func readOIDCDiscoveryDocument(ctx context.Context, url string) (*oidcDiscoveryDocument, error) {
+	issuer := strings.TrimSuffix(strings.TrimSuffix(url, OIDC_DISCOVERY_PATH), "/")
+	url = issuer + OIDC_DISCOVERY_PATH
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	httpr, err := idpMetadataHttpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch OIDC discovery document from %s. %s", url, err.Error())
+	}
+	defer httpr.Body.Close()
+	b, err := io.ReadAll(httpr.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch OIDC discovery document from %s. %s", url, err.Error())
+	}
+	if httpr.StatusCode >= 400 {
+		return nil, fmt.Errorf("unable to fetch OIDC discovery document from %s. %s: %s", url, httpr.Status, string(b))
+	}
+	var doc oidcDiscoveryDocument
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return nil, fmt.Errorf("unable to parse OIDC discovery document from %s. %s", url, err.Error())
+	}
+	// the issuer returned must be identical to the issuer used to locate the document
+	if strings.TrimSuffix(doc.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("the OIDC discovery document's issuer %q doesn't match the expected issuer %q", doc.Issuer, issuer)
+	}
+	if doc.AuthorizationEndpoint == "" || doc.TokenEndpoint == "" || doc.UserinfoEndpoint == "" {
+		return nil, fmt.Errorf("the OIDC discovery document from %s is missing at least one of the authorization, token or userinfo endpoints", url)
+	}
+	return &doc, nil
+}
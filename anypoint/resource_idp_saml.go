@@ -2,14 +2,28 @@ package anypoint
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
 	"io"
+	"net/http"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/mulesoft-anypoint/anypoint-client-go/idp"
 )
 
+// the metadata of identity providers are fetched without the platform's authentication, within a bounded time
+const IDP_METADATA_FETCH_TIMEOUT = 30 * time.Second
+
+var idpMetadataHttpClient = &http.Client{Timeout: IDP_METADATA_FETCH_TIMEOUT}
+
 func resourceSAML() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceSAMLCreate,
@@ -18,6 +32,7 @@ func resourceSAML() *schema.Resource {
 		DeleteContext: resourceSAMLDelete,
 		Description: `
 		Creates an ` + "`" + `identity provider` + "`" + ` SAML type configuration in your account.
+		The issuer, public keys and sign on/out urls can be read from the identity provider's metadata using ` + "`" + `metadata_xml` + "`" + ` or ` + "`" + `metadata_url` + "`" + `.
 		`,
 		Schema: map[string]*schema.Schema{
 			"last_updated": {
@@ -33,8 +48,9 @@ func resourceSAML() *schema.Resource {
 			},
 			"org_id": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The master organization id where the team is defined.",
+				Optional:    true,
+				Computed:    true,
+				Description: "The master organization id where the team is defined. Defaults to the provider's default_org_id.",
 			},
 			"provider_id": {
 				Type:        schema.TypeString,
@@ -51,16 +67,30 @@ func resourceSAML() *schema.Resource {
 				Computed:    true,
 				Description: "The type of the identity provider, contains description and the name of the type of the provider (saml or oidc)",
 			},
+			"metadata_xml": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"metadata_url"},
+				Description:   "The identity provider's SAML metadata (EntityDescriptor) in xml format. When set, the issuer, the public keys and the sign on/out urls are read from the metadata.",
+			},
+			"metadata_url": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ConflictsWith:    []string{"metadata_xml"},
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsURLWithHTTPorHTTPS),
+				Description:      "The url of the identity provider's SAML metadata. The metadata is fetched at plan time. When set, the issuer, the public keys and the sign on/out urls are read from the metadata.",
+			},
 			"saml": {
 				Type:        schema.TypeSet,
 				Description: "The description of identity provider specific for SAML types",
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"issuer": {
 							Type:        schema.TypeString,
-							Required:    true,
-							Description: "The provider issuer",
+							Optional:    true,
+							Description: "The provider issuer. Required unless metadata is provided.",
 						},
 						"audience": {
 							Type:        schema.TypeString,
@@ -69,8 +99,8 @@ func resourceSAML() *schema.Resource {
 						},
 						"public_key": {
 							Type:        schema.TypeList,
-							Required:    true,
-							Description: "The list of public keys",
+							Optional:    true,
+							Description: "The list of public keys. Required unless metadata is provided.",
 							Elem: &schema.Schema{
 								Type: schema.TypeString,
 							},
@@ -128,15 +158,62 @@ func resourceSAML() *schema.Resource {
 			},
 			"sp_sign_on_url": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The identity provider's sign on url",
+				Optional:    true,
+				Computed:    true,
+				Description: "The identity provider's sign on url. Required unless metadata is provided.",
 			},
 			"sp_sign_out_url": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The identity provider's sign out url, only available for SAML",
+				Optional:    true,
+				Computed:    true,
+				Description: "The identity provider's sign out url, only available for SAML. Required unless metadata provides it.",
+			},
+			"signing_certificates": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The details of the identity provider's signing certificates (public keys).",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"subject": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The certificate's subject.",
+						},
+						"issuer": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The certificate's issuer.",
+						},
+						"not_before": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The date (RFC3339) from which the certificate is valid.",
+						},
+						"not_after": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The certificate's expiration date (RFC3339).",
+						},
+						"sha256_fingerprint": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The certificate's SHA-256 fingerprint.",
+						},
+					},
+				},
+			},
+			"signing_certificate_expiry": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The earliest expiration date (RFC3339) among the signing certificates. Useful for alerting on certificate expiry.",
 			},
 		},
+		CustomizeDiff: func(ctx context.Context, rd *schema.ResourceDiff, i interface{}) error {
+			if err := customizeDiffProviderDefaults(ctx, rd, i); err != nil {
+				return err
+			}
+			return loadSAMLMetadataToDiff(ctx, rd)
+		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -216,6 +293,11 @@ func resourceSAMLRead(ctx context.Context, d *schema.ResourceData, m interface{}
 		})
 		return diags
 	}
+	//signing certificates details
+	samldata := res.GetSaml()
+	certs, expiry := flattenSAMLSigningCertificates(samldata.GetPublicKey())
+	d.Set("signing_certificates", certs)
+	d.Set("signing_certificate_expiry", expiry)
 
 	d.SetId(idpid)
 	d.Set("org_id", orgid)
@@ -459,3 +541,215 @@ func decomposeSAMLId(d *schema.ResourceData) (string, string) {
 	s := DecomposeResourceId(d.Id())
 	return s[0], s[1]
 }
+
+/*
+ * Parsing of the identity provider's SAML metadata
+ */
+
+type samlMetadataEntitiesDescriptor struct {
+	EntityDescriptors []samlMetadataEntityDescriptor `xml:"EntityDescriptor"`
+}
+
+type samlMetadataEntityDescriptor struct {
+	EntityID         string                        `xml:"entityID,attr"`
+	IDPSSODescriptor *samlMetadataIDPSSODescriptor `xml:"IDPSSODescriptor"`
+}
+
+type samlMetadataIDPSSODescriptor struct {
+	KeyDescriptors       []samlMetadataKeyDescriptor `xml:"KeyDescriptor"`
+	SingleSignOnServices []samlMetadataEndpoint      `xml:"SingleSignOnService"`
+	SingleLogoutServices []samlMetadataEndpoint      `xml:"SingleLogoutService"`
+}
+
+type samlMetadataKeyDescriptor struct {
+	Use          string   `xml:"use,attr"`
+	Certificates []string `xml:"KeyInfo>X509Data>X509Certificate"`
+}
+
+type samlMetadataEndpoint struct {
+	Binding  string `xml:"Binding,attr"`
+	Location string `xml:"Location,attr"`
+}
+
+type samlMetadata struct {
+	Issuer       string
+	Certificates []string
+	SignOnUrl    string
+	SignOutUrl   string
+}
+
+// populates the saml block and the sign on/out urls from the metadata (if provided) and validates the input
+func loadSAMLMetadataToDiff(ctx context.Context, rd *schema.ResourceDiff) error {
+	if !rd.NewValueKnown("metadata_xml") || !rd.NewValueKnown("metadata_url") || !rd.NewValueKnown("saml") {
+		return nil
+	}
+	metadata, err := readSAMLMetadata(ctx, rd.Get("metadata_xml").(string), rd.Get("metadata_url").(string))
+	if err != nil {
+		return err
+	}
+	saml := make(map[string]interface{})
+	if list := rd.Get("saml").(*schema.Set).List(); len(list) > 0 {
+		saml = list[0].(map[string]interface{})
+	}
+	if metadata != nil {
+		saml["issuer"] = metadata.Issuer
+		saml["public_key"] = metadata.Certificates
+		if err := rd.SetNew("saml", []interface{}{saml}); err != nil {
+			return err
+		}
+		if err := rd.SetNew("sp_sign_on_url", metadata.SignOnUrl); err != nil {
+			return err
+		}
+		if metadata.SignOutUrl != "" {
+			if err := rd.SetNew("sp_sign_out_url", metadata.SignOutUrl); err != nil {
+				return err
+			}
+		}
+	}
+	//validation
+	if val, ok := saml["audience"]; !ok || val.(string) == "" {
+		return fmt.Errorf("the saml block's audience is required")
+	}
+	if metadata == nil {
+		if val, ok := saml["issuer"]; !ok || val.(string) == "" {
+			return fmt.Errorf("the saml block's issuer is required when no metadata is provided")
+		}
+		if val, ok := saml["public_key"]; !ok || len(val.([]interface{})) == 0 {
+			return fmt.Errorf("the saml block's public_key is required when no metadata is provided")
+		}
+	}
+	if rd.NewValueKnown("sp_sign_on_url") && rd.Get("sp_sign_on_url").(string) == "" {
+		return fmt.Errorf("sp_sign_on_url is required when no metadata is provided")
+	}
+	if rd.NewValueKnown("sp_sign_out_url") && rd.Get("sp_sign_out_url").(string) == "" {
+		return fmt.Errorf("sp_sign_out_url is required when the metadata doesn't provide a single logout service")
+	}
+	if rd.HasChange("saml") {
+		rd.SetNewComputed("signing_certificates")
+		rd.SetNewComputed("signing_certificate_expiry")
+	}
+	return nil
+}
+
+// reads the metadata from the given xml content or url. returns nil if none is provided
+func readSAMLMetadata(ctx context.Context, content string, url string) (*samlMetadata, error) {
+	if content == "" && url == "" {
+		return nil, nil
+	}
+	if url != "" {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+		httpr, err := idpMetadataHttpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch SAML metadata from %s. %s", url, err.Error())
+		}
+		defer httpr.Body.Close()
+		b, err := io.ReadAll(httpr.Body)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch SAML metadata from %s. %s", url, err.Error())
+		}
+		if httpr.StatusCode >= 400 {
+			return nil, fmt.Errorf("unable to fetch SAML metadata from %s. %s: %s", url, httpr.Status, string(b))
+		}
+		content = string(b)
+	}
+	return parseSAMLMetadata([]byte(content))
+}
+
+// parses the EntityDescriptor of the identity provider. Supports EntitiesDescriptor by picking the first identity provider.
+func parseSAMLMetadata(content []byte) (*samlMetadata, error) {
+	var entity *samlMetadataEntityDescriptor
+	var single samlMetadataEntityDescriptor
+	if err := xml.Unmarshal(content, &single); err != nil {
+		return nil, fmt.Errorf("unable to parse SAML metadata. %s", err.Error())
+	}
+	if single.IDPSSODescriptor != nil {
+		entity = &single
+	} else {
+		var multiple samlMetadataEntitiesDescriptor
+		if err := xml.Unmarshal(content, &multiple); err == nil {
+			for i, e := range multiple.EntityDescriptors {
+				if e.IDPSSODescriptor != nil {
+					entity = &multiple.EntityDescriptors[i]
+					break
+				}
+			}
+		}
+	}
+	if entity == nil {
+		return nil, fmt.Errorf("unable to find an identity provider (IDPSSODescriptor) in the SAML metadata")
+	}
+	metadata := &samlMetadata{
+		Issuer:       entity.EntityID,
+		Certificates: make([]string, 0),
+	}
+	for _, kd := range entity.IDPSSODescriptor.KeyDescriptors {
+		// key descriptors without use are used for both signing and encryption
+		if kd.Use != "" && kd.Use != "signing" {
+			continue
+		}
+		for _, c := range kd.Certificates {
+			cert, err := DecodeX509Certificate(c)
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse SAML metadata signing certificate. %s", err.Error())
+			}
+			// public keys are provided as base64 encoded DER
+			key := base64.StdEncoding.EncodeToString(cert.Raw)
+			if !StringInSlice(metadata.Certificates, key, false) {
+				metadata.Certificates = append(metadata.Certificates, key)
+			}
+		}
+	}
+	if len(metadata.Certificates) == 0 {
+		return nil, fmt.Errorf("unable to find a signing certificate in the SAML metadata")
+	}
+	metadata.SignOnUrl = selectSAMLMetadataEndpoint(entity.IDPSSODescriptor.SingleSignOnServices)
+	if metadata.SignOnUrl == "" {
+		return nil, fmt.Errorf("unable to find a single sign on service in the SAML metadata")
+	}
+	metadata.SignOutUrl = selectSAMLMetadataEndpoint(entity.IDPSSODescriptor.SingleLogoutServices)
+	return metadata, nil
+}
+
+// selects the endpoint's location, HTTP-Redirect binding is preferred over the others
+func selectSAMLMetadataEndpoint(endpoints []samlMetadataEndpoint) string {
+	for _, e := range endpoints {
+		if strings.HasSuffix(e.Binding, ":HTTP-Redirect") {
+			return e.Location
+		}
+	}
+	if len(endpoints) > 0 {
+		return endpoints[0].Location
+	}
+	return ""
+}
+
+// returns the details of the given public keys along with the earliest expiry date
+func flattenSAMLSigningCertificates(keys []string) ([]interface{}, string) {
+	certs := make([]interface{}, 0)
+	expiries := make([]string, 0)
+	for _, key := range keys {
+		cert, err := DecodeX509Certificate(key)
+		if err != nil {
+			continue
+		}
+		fingerprint := sha256.Sum256(cert.Raw)
+		not_after := cert.NotAfter.UTC().Format(time.RFC3339)
+		certs = append(certs, map[string]interface{}{
+			"subject":            cert.Subject.String(),
+			"issuer":             cert.Issuer.String(),
+			"not_before":         cert.NotBefore.UTC().Format(time.RFC3339),
+			"not_after":          not_after,
+			"sha256_fingerprint": hex.EncodeToString(fingerprint[:]),
+		})
+		expiries = append(expiries, not_after)
+	}
+	if len(expiries) == 0 {
+		return certs, ""
+	}
+	// RFC3339 dates in UTC are sorted lexicographically
+	sort.Strings(expiries)
+	return certs, expiries[0]
+}
@@ -0,0 +1,307 @@
+package anypoint
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type orgSecuritySettingsPutBody struct {
+	MfaRequired    string `json:"mfaRequired,omitempty"`
+	SessionTimeout int    `json:"sessionTimeout,omitempty"`
+	IdProviderId   string `json:"idprovider_id,omitempty"`
+}
+
+type orgSecuritySettingsUserPutBody struct {
+	MfaVerificationExcluded bool `json:"mfaVerificationExcluded"`
+}
+
+func resourceOrgSecuritySettings() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceOrgSecuritySettingsCreate,
+		ReadContext:   resourceOrgSecuritySettingsRead,
+		UpdateContext: resourceOrgSecuritySettingsUpdate,
+		DeleteContext: resourceOrgSecuritySettingsDelete,
+		CustomizeDiff: customizeDiffProviderDefaults,
+		Description: `
+		Manages the security settings of the root organization or of a business group: MFA enforcement, users exempted from MFA, session timeout and default identity provider.
+		Only the configured settings are managed, the others are left unchanged.
+		The session timeout of a business group managed with ` + "`" + `anypoint_bg` + "`" + ` should be set on only one of the two resources, otherwise they overwrite each other.
+		The delete operation only removes the resource from local terraform state file, the settings are left as they are.
+		`,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The id of the organization.",
+			},
+			"org_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The id of the root organization or business group. Defaults to the provider's default_org_id.",
+			},
+			"mfa_required": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Description:      "Whether MFA is enforced for the users of the organization. Either enabled or disabled.",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"enabled", "disabled"}, false)),
+			},
+			"mfa_exempt_user_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The ids of the users exempted from MFA verification.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"session_timeout": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Computed:         true,
+				Description:      "The session timeout of the organization's users in minutes. Don't set it if the session_timeout of the business group is set with anypoint_bg, the two resources would overwrite each other.",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(15, 180)),
+			},
+			"default_identity_provider_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The id of the identity provider used by default to sign in the external users of the organization.",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceOrgSecuritySettingsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	orgid := d.Get("org_id").(string)
+	if diags := updateOrgSecuritySettings(ctx, d, m, true); diags.HasError() {
+		return diags
+	}
+	d.SetId(orgid)
+	return resourceOrgSecuritySettingsRead(ctx, d, m)
+}
+
+func resourceOrgSecuritySettingsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid := d.Id()
+	authctx := getAccountsAuthCtx(ctx, &pco)
+	//perform request
+	var res map[string]interface{}
+	httpr, err := pco.accountsclient.Execute(authctx, http.MethodGet, getOrgSecuritySettingsPath(orgid), nil, &res)
+	if err != nil {
+		var details string
+		if httpr != nil && httpr.StatusCode >= 400 {
+			defer httpr.Body.Close()
+			b, _ := io.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		diags := append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to read security settings of org " + orgid,
+			Detail:   details,
+		})
+		return diags
+	}
+	defer httpr.Body.Close()
+	//process data
+	data := flattenOrgSecuritySettings(res)
+	// exemptions are only tracked for the managed users
+	if users, ok := d.GetOk("mfa_exempt_user_ids"); ok {
+		exempted, err := readOrgMfaExemptUsers(ctx, &pco, orgid, users.(*schema.Set).List())
+		if err != nil {
+			diags := append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to read MFA exempted users of org " + orgid,
+				Detail:   err.Error(),
+			})
+			return diags
+		}
+		data["mfa_exempt_user_ids"] = exempted
+	}
+	if err := setOrgSecuritySettingsAttributesToResourceData(d, data); err != nil {
+		diags := append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to set security settings of org " + orgid,
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	d.Set("org_id", orgid)
+
+	return diags
+}
+
+func resourceOrgSecuritySettingsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if diags := updateOrgSecuritySettings(ctx, d, m, false); diags.HasError() {
+		return diags
+	}
+	return resourceOrgSecuritySettingsRead(ctx, d, m)
+}
+
+func resourceOrgSecuritySettingsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	// NOTE: the security settings of an organization cannot be deleted
+	// Therefore we are only removing reference here
+	d.SetId("")
+	return diags
+}
+
+// updates the organization settings and the users MFA exemptions.
+// On creation, all configured settings are sent, otherwise only the changed ones.
+func updateOrgSecuritySettings(ctx context.Context, d *schema.ResourceData, m interface{}, create bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid := d.Get("org_id").(string)
+	authctx := getAccountsAuthCtx(ctx, &pco)
+	if create || d.HasChanges("mfa_required", "session_timeout", "default_identity_provider_id") {
+		body := newOrgSecuritySettingsPutBody(d)
+		httpr, err := pco.accountsclient.Execute(authctx, http.MethodPut, getOrgSecuritySettingsPath(orgid), body, nil)
+		if err != nil {
+			var details string
+			if httpr != nil && httpr.StatusCode >= 400 {
+				defer httpr.Body.Close()
+				b, _ := io.ReadAll(httpr.Body)
+				details = string(b)
+			} else {
+				details = err.Error()
+			}
+			diags := append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to update security settings of org " + orgid,
+				Detail:   details,
+			})
+			return diags
+		}
+		defer httpr.Body.Close()
+	}
+	if create || d.HasChange("mfa_exempt_user_ids") {
+		old, new := d.GetChange("mfa_exempt_user_ids")
+		added := new.(*schema.Set).Difference(old.(*schema.Set)).List()
+		removed := old.(*schema.Set).Difference(new.(*schema.Set)).List()
+		for _, userid := range added {
+			if err := setOrgUserMfaExempt(ctx, &pco, orgid, userid.(string), true); err != nil {
+				diags := append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Unable to exempt user " + userid.(string) + " from MFA in org " + orgid,
+					Detail:   err.Error(),
+				})
+				return diags
+			}
+		}
+		for _, userid := range removed {
+			if err := setOrgUserMfaExempt(ctx, &pco, orgid, userid.(string), false); err != nil {
+				diags := append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Unable to remove MFA exemption of user " + userid.(string) + " in org " + orgid,
+					Detail:   err.Error(),
+				})
+				return diags
+			}
+		}
+	}
+	return diags
+}
+
+// prepares the organization update body, only the configured settings are sent
+func newOrgSecuritySettingsPutBody(d *schema.ResourceData) *orgSecuritySettingsPutBody {
+	body := &orgSecuritySettingsPutBody{}
+	if val, ok := d.GetOk("mfa_required"); ok {
+		body.MfaRequired = val.(string)
+	}
+	if val, ok := d.GetOk("session_timeout"); ok {
+		body.SessionTimeout = val.(int)
+	}
+	if val, ok := d.GetOk("default_identity_provider_id"); ok {
+		body.IdProviderId = val.(string)
+	}
+	return body
+}
+
+// returns the given users that are exempted from MFA verification
+func readOrgMfaExemptUsers(ctx context.Context, pco *ProviderConfOutput, orgid string, userids []interface{}) ([]interface{}, error) {
+	authctx := getAccountsAuthCtx(ctx, pco)
+	exempted := make([]interface{}, 0, len(userids))
+	for _, userid := range userids {
+		var res map[string]interface{}
+		httpr, err := pco.accountsclient.Execute(authctx, http.MethodGet, getOrgSecuritySettingsUserPath(orgid, userid.(string)), nil, &res)
+		if err != nil {
+			if httpr != nil && httpr.StatusCode == http.StatusNotFound {
+				continue
+			}
+			return nil, fmt.Errorf("unable to read user %s. %s", userid, getHttpErrorDetails(httpr, err))
+		}
+		httpr.Body.Close()
+		if val, ok := res["mfaVerificationExcluded"].(bool); ok && val {
+			exempted = append(exempted, userid)
+		}
+	}
+	return exempted, nil
+}
+
+func setOrgUserMfaExempt(ctx context.Context, pco *ProviderConfOutput, orgid, userid string, exempt bool) error {
+	authctx := getAccountsAuthCtx(ctx, pco)
+	body := &orgSecuritySettingsUserPutBody{MfaVerificationExcluded: exempt}
+	httpr, err := pco.accountsclient.Execute(authctx, http.MethodPut, getOrgSecuritySettingsUserPath(orgid, userid), body, nil)
+	if err != nil {
+		return fmt.Errorf("%s", getHttpErrorDetails(httpr, err))
+	}
+	httpr.Body.Close()
+	return nil
+}
+
+func flattenOrgSecuritySettings(org map[string]interface{}) map[string]interface{} {
+	item := make(map[string]interface{})
+	if val, ok := org["mfaRequired"].(string); ok {
+		item["mfa_required"] = val
+	}
+	if val, ok := org["sessionTimeout"].(float64); ok {
+		item["session_timeout"] = int(val)
+	}
+	if val, ok := org["idprovider_id"].(string); ok {
+		item["default_identity_provider_id"] = val
+	}
+	return item
+}
+
+func setOrgSecuritySettingsAttributesToResourceData(d *schema.ResourceData, data map[string]interface{}) error {
+	attributes := getOrgSecuritySettingsAttributes()
+	if data != nil {
+		for _, attr := range attributes {
+			if val, ok := data[attr]; ok {
+				if err := d.Set(attr, val); err != nil {
+					return fmt.Errorf("unable to set org security settings attribute %s\n\tdetails: %s", attr, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func getOrgSecuritySettingsAttributes() []string {
+	attributes := [...]string{
+		"mfa_required", "mfa_exempt_user_ids", "session_timeout", "default_identity_provider_id",
+	}
+	return attributes[:]
+}
+
+func getOrgSecuritySettingsPath(orgid string) string {
+	return fmt.Sprintf("/organizations/%s", url.PathEscape(orgid))
+}
+
+func getOrgSecuritySettingsUserPath(orgid, userid string) string {
+	return fmt.Sprintf("/organizations/%s/users/%s", url.PathEscape(orgid), url.PathEscape(userid))
+}
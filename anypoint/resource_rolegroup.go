@@ -18,6 +18,7 @@ func resourceRoleGroup() *schema.Resource {
 		ReadContext:   resourceRoleGroupRead,
 		UpdateContext: resourceRoleGroupUpdate,
 		DeleteContext: resourceRoleGroupDelete,
+		CustomizeDiff: customizeDiffProviderDefaults,
 		DeprecationMessage: `
 		This resource is deprecated, please use ` + "`" + `teams` + "`" + `, ` + "`" + `team_members` + "`" + `team_roles` + "`" + ` instead.
 		`,
@@ -64,9 +65,10 @@ func resourceRoleGroup() *schema.Resource {
 			},
 			"org_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The master orgnization id where the role-group is defined",
+				Description: "The master orgnization id where the role-group is defined. Defaults to the provider's default_org_id.",
 			},
 			"editable": {
 				Type:        schema.TypeBool,
@@ -332,7 +334,7 @@ func getRolegroupWatchAttributes() []string {
  * Returns authentication context (includes authorization header)
  */
 func getRoleGroupAuthCtx(ctx context.Context, pco *ProviderConfOutput) context.Context {
-	tmp := context.WithValue(ctx, rolegroup.ContextAccessToken, pco.access_token)
+	tmp := context.WithValue(ctx, rolegroup.ContextAccessToken, pco.tokensource.Token(ctx))
 	return context.WithValue(tmp, rolegroup.ContextServerIndex, pco.server_index)
 }
 
@@ -15,6 +15,17 @@ func resourceRoleGroupRoles() *schema.Resource {
 		CreateContext: resourceRoleGroupRolesCreate,
 		ReadContext:   resourceRoleGroupRolesRead,
 		DeleteContext: resourceRoleGroupRolesDelete,
+		CustomizeDiff: func(ctx context.Context, rd *schema.ResourceDiff, i interface{}) error {
+			if err := customizeDiffProviderDefaults(ctx, rd, i); err != nil {
+				return err
+			}
+			if !rd.HasChange("roles") || !rd.NewValueKnown("org_id") {
+				return nil
+			}
+			pco := i.(ProviderConfOutput)
+			// the roles are assigned in the business group context only
+			return validateRolesContextParams(ctx, rd, &pco, rd.Get("org_id").(string), false)
+		},
 		DeprecationMessage: `
 		This resource is deprecated, please use ` + "`" + `teams` + "`" + `, ` + "`" + `team_members` + "`" + `team_roles` + "`" + ` instead.
 		`,
@@ -35,9 +46,10 @@ func resourceRoleGroupRoles() *schema.Resource {
 			},
 			"org_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The business group id",
+				Description: "The business group id. Defaults to the provider's default_org_id.",
 			},
 			"total": {
 				Type:        schema.TypeInt,
@@ -345,7 +357,7 @@ func getAssignedRolesAttributes() []string {
  * Returns authentication context (includes authorization header)
  */
 func getRoleAuthCtx(ctx context.Context, pco *ProviderConfOutput) context.Context {
-	tmp := context.WithValue(ctx, role.ContextAccessToken, pco.access_token)
+	tmp := context.WithValue(ctx, role.ContextAccessToken, pco.tokensource.Token(ctx))
 	return context.WithValue(tmp, role.ContextServerIndex, pco.server_index)
 }
 
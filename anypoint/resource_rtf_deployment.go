@@ -3,6 +3,7 @@ package anypoint
 import (
 	"context"
 	"io"
+	"net/http"
 	"regexp"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -126,16 +127,16 @@ var DeplApplicationRTFDefinition = &schema.Resource{
 			Description: "The status of the application.",
 		},
 		"desired_state": {
-			Type:        schema.TypeString,
-			Optional:    true,
-			Default:     "STARTED",
-			Description: "The desired state of the application.",
+			Type:     schema.TypeString,
+			Optional: true,
+			Default:  APP_DEPLOYMENT_V2_APP_STATE_STARTED,
+			Description: `
+			The desired state of the application: STARTED or STOPPED.
+			When it is the only change, the application is started or stopped without being redeployed.
+			`,
 			ValidateDiagFunc: validation.ToDiagFunc(
 				validation.StringInSlice(
-					[]string{
-						"PARTIALLY_STARTED", "DEPLOYMENT_FAILED", "STARTING", "STARTED", "STOPPING",
-						"STOPPED", "UNDEPLOYING", "UNDEPLOYED", "UPDATED", "APPLIED", "APPLYING", "FAILED", "DELETED",
-					},
+					[]string{APP_DEPLOYMENT_V2_APP_STATE_STARTED, APP_DEPLOYMENT_V2_APP_STATE_STOPPED},
 					false,
 				),
 			),
@@ -146,7 +147,7 @@ var DeplApplicationRTFDefinition = &schema.Resource{
 			Required: true,
 			Description: `
 			The reference to the artifact on Exchange that is to be deployed on Runtime Fabrics.
-			Please ensure the application's artifact is deployed on Exchange before using this resource on Runtime Fabrics.
+			Please ensure the application's artifact is deployed on Exchange before using this resource on Runtime Fabrics, or use artifact_path to publish a local artifact.
 			`,
 			Elem: DeplApplicationRefRTFDefinition,
 		},
@@ -177,6 +178,8 @@ var DeplTargetDeplSettHttpRTFDefinition = &schema.Resource{
 			Description: `The ingress url(s).
 			If you need to use multiple ingress urls, separete them with commas.
 			example: http://example.mulesoft.terraform.net/(.+)
+			Note: previous versions of the provider didn't send this value to the platform. It is now applied,
+			deployments having a value set get it at their next update.
 			`,
 			Optional: true,
 			Default:  "",
@@ -498,8 +501,21 @@ func resourceRTFDeployment() *schema.Resource {
 		ReadContext:   resourceRTFDeploymentRead,
 		UpdateContext: resourceRTFDeploymentUpdate,
 		DeleteContext: resourceRTFDeploymentDelete,
+		CustomizeDiff: func(ctx context.Context, rd *schema.ResourceDiff, i interface{}) error {
+			if err := customizeDiffProviderDefaults(ctx, rd, i); err != nil {
+				return err
+			}
+			if err := validateAppDeploymentV2CandidateRollout(rd); err != nil {
+				return err
+			}
+			return customizeDiffArtifactHash(rd)
+		},
 		Description: `
 		Creates and manages a ` + "`" + `deployment` + "`" + ` of a mule app on Runtime Fabrics only.
+		Create and update operations wait until the deployment is applied and the application is running, the latest log lines of the deployment are reported in case of failure.
+		The application can be started, stopped or restarted (restart_trigger) without being redeployed.
+		Changes are rolled out in place, or with a candidate deployment taking over the public url once running (canary) or once promoted (blue_green).
+		Failed rollouts are rolled back automatically to the last successful version unless rollout.auto_rollback is false.
 		`,
 		Schema: map[string]*schema.Schema{
 			"id": {
@@ -509,15 +525,17 @@ func resourceRTFDeployment() *schema.Resource {
 			},
 			"org_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The organization where the mule app is deployed.",
+				Description: "The organization where the mule app is deployed. Defaults to the provider's default_org_id.",
 			},
 			"env_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The environment where mule app is deployed.",
+				Description: "The environment where mule app is deployed. Defaults to the provider's default_env_id or default_env_name.",
 			},
 			"name": {
 				Type:        schema.TypeString,
@@ -570,6 +588,44 @@ func resourceRTFDeployment() *schema.Resource {
 				Description: "The last successfully deployed version",
 				Computed:    true,
 			},
+			"artifact_path": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: `
+				The path of a local mule application jar file.
+				The file is published to Exchange under the group_id, artifact_id and version of the application ref before being deployed.
+				Exchange versions are immutable, the version has to be incremented when the content of the file changes.
+				`,
+			},
+			"artifact_hash": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The sha256 checksum of the local application jar file given by artifact_path.",
+			},
+			"rollout": {
+				Type:        schema.TypeList,
+				MaxItems:    1,
+				Optional:    true,
+				Description: "The rollout of the changes of the deployment.",
+				Elem:        DeplRolloutDefinition,
+			},
+			"restart_trigger": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Arbitrary map of values that, when changed, performs a rolling restart of the replicas of the application without redeploying it. The restart is skipped with a warning when the application is not STARTED or when the deployment has other changes.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"candidate_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The id of the candidate deployment waiting for its promotion (blue_green strategy) or kept after a failed rollout without auto_rollback.",
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(APP_DEPLOYMENT_V2_DEFAULT_TIMEOUT),
+			Update: schema.DefaultTimeout(APP_DEPLOYMENT_V2_DEFAULT_TIMEOUT),
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -583,6 +639,15 @@ func resourceRTFDeploymentCreate(ctx context.Context, d *schema.ResourceData, m
 	name := d.Get("name").(string)
 	orgid := d.Get("org_id").(string)
 	envid := d.Get("env_id").(string)
+	//publish the local artifact
+	if err := publishAppDeploymentV2Artifact(ctx, &pco, d); err != nil {
+		diags := append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to publish the artifact of " + name + " deployment to exchange.",
+			Detail:   err.Error(),
+		})
+		return diags
+	}
 	authctx := getAppDeploymentV2AuthCtx(ctx, &pco)
 	body := newRTFDeploymentBody(d)
 	//Execute post deployment
@@ -605,6 +670,15 @@ func resourceRTFDeploymentCreate(ctx context.Context, d *schema.ResourceData, m
 	}
 	defer httpr.Body.Close()
 	d.SetId(res.GetId())
+	//wait for the deployment to be applied
+	if _, err := waitAppDeploymentV2Ready(ctx, &pco, orgid, envid, res.GetId(), d.Timeout(schema.TimeoutCreate)); err != nil {
+		diags := append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to create " + name + " deployment for runtime fabrics.",
+			Detail:   err.Error() + getAppDeploymentV2LogsTail(ctx, &pco, orgid, envid, res.GetId(), res.GetDesiredVersion()),
+		})
+		return diags
+	}
 	return resourceRTFDeploymentRead(ctx, d, m)
 }
 
@@ -617,9 +691,22 @@ func resourceRTFDeploymentRead(ctx context.Context, d *schema.ResourceData, m in
 	if isComposedResourceId(id) {
 		orgid, envid, id = decomposeRTFDeploymentId(d)
 	}
+	name := d.Get("name").(string)
+	// the attributes of a pending candidate are the ones of the configuration
+	readid := id
+	if candidateid := d.Get("candidate_id").(string); candidateid != "" {
+		readid = candidateid
+	}
 	authctx := getAppDeploymentV2AuthCtx(ctx, &pco)
 	//perform request
-	res, httpr, err := pco.appmanagerclient.DefaultApi.GetDeploymentById(authctx, orgid, envid, id).Execute()
+	res, httpr, err := pco.appmanagerclient.DefaultApi.GetDeploymentById(authctx, orgid, envid, readid).Execute()
+	if err != nil && readid != id && httpr != nil && httpr.StatusCode == http.StatusNotFound {
+		// the candidate has been deleted by a rolled back promotion or outside of terraform
+		httpr.Body.Close()
+		d.Set("candidate_id", "")
+		readid = id
+		res, httpr, err = pco.appmanagerclient.DefaultApi.GetDeploymentById(authctx, orgid, envid, readid).Execute()
+	}
 	if err != nil {
 		var details string
 		if httpr != nil && httpr.StatusCode >= 400 {
@@ -631,7 +718,7 @@ func resourceRTFDeploymentRead(ctx context.Context, d *schema.ResourceData, m in
 		}
 		diags := append(diags, diag.Diagnostic{
 			Severity: diag.Error,
-			Summary:  "Unable to read runtime fabrics deployment " + id + ".",
+			Summary:  "Unable to read runtime fabrics deployment " + readid + ".",
 			Detail:   details,
 		})
 		return diags
@@ -648,8 +735,9 @@ func resourceRTFDeploymentRead(ctx context.Context, d *schema.ResourceData, m in
 		})
 		return diags
 	}
+	restoreAppDeploymentV2Name(d, name)
 	// setting all params required for reading in case of import
-	d.SetId(res.GetId())
+	d.SetId(id)
 	d.Set("org_id", orgid)
 	d.Set("env_id", envid)
 
@@ -662,31 +750,21 @@ func resourceRTFDeploymentUpdate(ctx context.Context, d *schema.ResourceData, m
 		return diags
 	}
 	pco := m.(ProviderConfOutput)
-	id := d.Id()
-	orgid := d.Get("org_id").(string)
-	envid := d.Get("env_id").(string)
 	name := d.Get("name").(string)
-	authctx := getAppDeploymentV2AuthCtx(ctx, &pco)
-	body := newRTFDeploymentBody(d)
-	_, httpr, err := pco.appmanagerclient.DefaultApi.PatchDeployment(authctx, orgid, envid, id).DeploymentRequestBody(*body).Execute()
-	if err != nil {
-		var details string
-		if httpr != nil && httpr.StatusCode >= 400 {
-			defer httpr.Body.Close()
-			b, _ := io.ReadAll(httpr.Body)
-			details = string(b)
-		} else {
-			details = err.Error()
-		}
+	//publish the local artifact
+	if err := publishAppDeploymentV2Artifact(ctx, &pco, d); err != nil {
 		diags := append(diags, diag.Diagnostic{
 			Severity: diag.Error,
-			Summary:  "Unable to update deployment " + name + " on runtime fabrics.",
-			Detail:   details,
+			Summary:  "Unable to publish the artifact of " + name + " deployment to exchange.",
+			Detail:   err.Error(),
 		})
 		return diags
 	}
-	defer httpr.Body.Close()
-	return resourceRTFDeploymentRead(ctx, d, m)
+	diags = append(diags, updateAppDeploymentV2(ctx, d, &pco, newRTFDeploymentBodyFromConfig, "Unable to update deployment "+name+" on runtime fabrics.")...)
+	if diags.HasError() {
+		return diags
+	}
+	return append(diags, resourceRTFDeploymentRead(ctx, d, m)...)
 }
 
 func resourceRTFDeploymentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -696,6 +774,18 @@ func resourceRTFDeploymentDelete(ctx context.Context, d *schema.ResourceData, m
 	orgid := d.Get("org_id").(string)
 	envid := d.Get("env_id").(string)
 	name := d.Get("name").(string)
+	//delete the pending candidate first
+	if candidateid := d.Get("candidate_id").(string); candidateid != "" {
+		if err := deleteAppDeploymentV2(ctx, &pco, orgid, envid, candidateid); err != nil {
+			diags := append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to delete candidate deployment of " + name + " on runtime fabrics.",
+				Detail:   err.Error(),
+			})
+			return diags
+		}
+		d.Set("candidate_id", "")
+	}
 	authctx := getAppDeploymentV2AuthCtx(ctx, &pco)
 	httpr, err := pco.appmanagerclient.DefaultApi.DeleteDeployment(authctx, orgid, envid, id).Execute()
 	if err != nil {
@@ -723,17 +813,22 @@ func resourceRTFDeploymentDelete(ctx context.Context, d *schema.ResourceData, m
 
 // Prepares Deployment Post Body out of resource data input
 func newRTFDeploymentBody(d *schema.ResourceData) *application_manager_v2.DeploymentRequestBody {
-	body := application_manager_v2.NewDeploymentRequestBody()
-	// -- Parsing Application
 	app_list_d := d.Get("application").([]interface{})
 	app_d := app_list_d[0].(map[string]interface{})
-	application := newRTFDeploymentApplication(app_d)
-	// -- Parsing Target
 	target_list_d := d.Get("target").([]interface{})
 	target_d := target_list_d[0].(map[string]interface{})
+	return newRTFDeploymentBodyFromConfig(d.Get("name").(string), app_d, target_d)
+}
+
+// Prepares Deployment Body of the given name out of application and target inputs
+func newRTFDeploymentBodyFromConfig(name string, app_d map[string]interface{}, target_d map[string]interface{}) *application_manager_v2.DeploymentRequestBody {
+	body := application_manager_v2.NewDeploymentRequestBody()
+	// -- Parsing Application
+	application := newRTFDeploymentApplication(app_d)
+	// -- Parsing Target
 	target := newRTFDeploymentTarget(target_d)
 	//Set Body Data
-	body.SetName(d.Get("name").(string))
+	body.SetName(name)
 	body.SetApplication(*application)
 	body.SetTarget(*target)
 
@@ -880,6 +975,9 @@ func newRTFDeploymentHttp(deployment_settings_d map[string]interface{}) *applica
 		http_list_d := val.([]interface{})
 		if len(http_list_d) > 0 {
 			http_d := http_list_d[0].(map[string]interface{})
+			if url := http_d["inbound_public_url"].(string); url != "" {
+				http_inbound.SetPublicUrl(url)
+			}
 			http_inbound.SetLastMileSecurity(http_d["inbound_last_mile_security"].(bool))
 			http_inbound.SetForwardSslSession(http_d["inbound_forward_ssl_session"].(bool))
 			http.SetInbound(*http_inbound)
@@ -927,6 +1025,6 @@ func decomposeRTFDeploymentId(d *schema.ResourceData) (string, string, string) {
 }
 
 func getRTFDeploymentUpdatableAttributes() []string {
-	attributes := [...]string{"application", "target"}
+	attributes := [...]string{"application", "target", "artifact_path", "artifact_hash", "rollout", "restart_trigger"}
 	return attributes[:]
 }
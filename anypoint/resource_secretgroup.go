@@ -16,8 +16,17 @@ func resourceSecretGroup() *schema.Resource {
 		ReadContext:   resourceSecretGroupRead,
 		UpdateContext: resourceSecretGroupUpdate,
 		DeleteContext: resourceSecretGroupDelete,
+		CustomizeDiff: func(ctx context.Context, rd *schema.ResourceDiff, i interface{}) error {
+			if err := customizeDiffProviderDefaults(ctx, rd, i); err != nil {
+				return err
+			}
+			pco := i.(ProviderConfOutput)
+			return customizeDiffEnvDeletionProtection(ctx, rd, &pco)
+		},
 		Description: `
 		Create a secret group for a given organization and environment.
+		Anypoint locks the secret group during edits, the update and delete operations wait until the secret group is clear and unlocked.
+		Use the resource anypoint_secretgroup_finalize to finalize the secret group once its contents are populated.
 		`,
 		Schema: map[string]*schema.Schema{
 			"last_updated": {
@@ -32,15 +41,17 @@ func resourceSecretGroup() *schema.Resource {
 			},
 			"org_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The organization id where the secret group instance is defined.",
+				Description: "The organization id where the secret group instance is defined. Defaults to the provider's default_org_id.",
 			},
 			"env_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The environment id where the secret group instance is defined.",
+				Description: "The environment id where the secret group instance is defined. Defaults to the provider's default_env_id or default_env_name.",
 			},
 			"name": {
 				Type:        schema.TypeString,
@@ -53,6 +64,12 @@ func resourceSecretGroup() *schema.Resource {
 				ForceNew:    true,
 				Description: "Setting this to true indicates that the secrets from this secret group are allowed to be downloadable by end users, altough, through other applications.",
 			},
+			"force_unlock": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "With 'true' to cancel the lock held on the secret group (by any user) before an update or a delete, pending changes of the lock holder are discarded. Otherwise the operation waits until the lock is released.",
+			},
 			"created_at": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -89,6 +106,16 @@ func resourceSecretGroup() *schema.Resource {
 					* If currentState="Deleting", only operation allowed - delete secret group.
 				`,
 			},
+			"deletion_protection": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the secret group is protected from deletion. While enabled, the secret group cannot be destroyed or replaced, it has to be set to false in an apply first. Defaults to true if the type of the secret group's environment is listed in the provider's protected_environment_types, false otherwise.",
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Update: schema.DefaultTimeout(SG_LOCK_DEFAULT_TIMEOUT),
+			Delete: schema.DefaultTimeout(SG_LOCK_DEFAULT_TIMEOUT),
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -182,6 +209,15 @@ func resourceSecretGroupUpdate(ctx context.Context, d *schema.ResourceData, m in
 		orgid := d.Get("org_id").(string)
 		envid := d.Get("env_id").(string)
 		id := d.Get("id").(string)
+		force_unlock := d.Get("force_unlock").(bool)
+		if _, err := waitSecretGroupClear(ctx, &pco, orgid, envid, id, d.Timeout(schema.TimeoutUpdate), force_unlock, false); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to update secret group " + id,
+				Detail:   err.Error(),
+			})
+			return diags
+		}
 		authctx := getSecretGroupAuthCtx(ctx, &pco)
 		body := newSecretGroupPatchBody(d)
 		_, httpr, err := pco.secretgroupclient.DefaultApi.PatchSecretGroup(authctx, orgid, envid, id).SecretGroupPatchBody(*body).Execute()
@@ -211,10 +247,22 @@ func resourceSecretGroupUpdate(ctx context.Context, d *schema.ResourceData, m in
 
 func resourceSecretGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
+	if diags := checkDeletionProtection(d, "Unable to delete secret group "+d.Id()); diags != nil {
+		return diags
+	}
 	pco := m.(ProviderConfOutput)
 	orgid := d.Get("org_id").(string)
 	envid := d.Get("env_id").(string)
 	id := d.Get("id").(string)
+	force_unlock := d.Get("force_unlock").(bool)
+	if _, err := waitSecretGroupClear(ctx, &pco, orgid, envid, id, d.Timeout(schema.TimeoutDelete), force_unlock, false); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to delete secret group " + id,
+			Detail:   err.Error(),
+		})
+		return diags
+	}
 	authctx := getSecretGroupAuthCtx(ctx, &pco)
 	httpr, err := pco.secretgroupclient.DefaultApi.DeleteSecretGroup(authctx, orgid, envid, id).Execute()
 	if err != nil {
@@ -269,6 +317,6 @@ func decomposeSecretGroupId(d *schema.ResourceData) (string, string, string) {
  * Returns authentication context (includes authorization header)
  */
 func getSecretGroupAuthCtx(ctx context.Context, pco *ProviderConfOutput) context.Context {
-	tmp := context.WithValue(ctx, secretgroup.ContextAccessToken, pco.access_token)
+	tmp := context.WithValue(ctx, secretgroup.ContextAccessToken, pco.tokensource.Token(ctx))
 	return context.WithValue(tmp, secretgroup.ContextServerIndex, pco.server_index)
 }
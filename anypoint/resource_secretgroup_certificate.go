@@ -2,8 +2,8 @@ package anypoint
 
 import (
 	"context"
+	"fmt"
 	"io"
-	"os"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -42,15 +42,17 @@ func resourceSecretGroupCertificate() *schema.Resource {
 			},
 			"org_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The organization id where the certificate's secret group is defined.",
+				Description: "The organization id where the certificate's secret group is defined. Defaults to the provider's default_org_id.",
 			},
 			"env_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The environment id where the certificate's secret group is defined.",
+				Description: "The environment id where the certificate's secret group is defined. Defaults to the provider's default_env_id or default_env_name.",
 			},
 			"allow_expired_cert": {
 				Type:        schema.TypeBool,
@@ -58,6 +60,12 @@ func resourceSecretGroupCertificate() *schema.Resource {
 				Default:     false,
 				Description: "With 'true' to allow uploading expired certificates",
 			},
+			"min_validity_days": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+				Description:      "When set, fails the plan if a certificate to upload expires within the given number of days.",
+			},
 			"name": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -75,9 +83,23 @@ func resourceSecretGroupCertificate() *schema.Resource {
 				),
 			},
 			"certificate": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ConflictsWith:    []string{"certificate_content"},
+				DiffSuppressFunc: DiffSuppressFunc4FilePath("certificate_digest"),
+				Description:      "The path to The file containing the certificate in PEM format. Required unless certificate_content is provided. Moving or renaming the file with the same content doesn't cause a diff.",
+			},
+			"certificate_content": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"certificate"},
+				Description:   "The content of the certificate in PEM format. Mutually exclusive with certificate.",
+			},
+			"certificate_digest": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The path to The file containing the certificate in PEM format",
+				Computed:    true,
+				Description: "The SHA-256 digest of the uploaded certificate content. Used to detect changes of the certificate file or content.",
 			},
 			"expiration_date": {
 				Type:        schema.TypeString,
@@ -103,6 +125,18 @@ func resourceSecretGroupCertificate() *schema.Resource {
 				},
 			},
 		},
+		CustomizeDiff: func(ctx context.Context, rd *schema.ResourceDiff, i interface{}) error {
+			if err := customizeDiffProviderDefaults(ctx, rd, i); err != nil {
+				return err
+			}
+			if !IsFileInputSet(rd, "certificate") {
+				return fmt.Errorf("missing required attribute \"certificate\" path (or \"certificate_content\")")
+			}
+			if err := CustomizeDiff4FileInputDigests(rd, "certificate"); err != nil {
+				return err
+			}
+			return CustomizeDiff4MinValidityDays(rd, "certificate")
+		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -120,7 +154,9 @@ func resourceSecretGroupCertificateCreate(ctx context.Context, d *schema.Resourc
 	authctx := getSgCertificateAuthCtx(ctx, &pco)
 	//prepare request
 	req := pco.sgcertificateclient.DefaultApi.PostSecretGroupCertificate(authctx, orgid, envid, sgid).AllowExpiredCert(allow_expired_cert)
-	req, err := loadSgCertificatePostBody(req, d)
+	inputs := &FileInputs{}
+	defer CloseFileInputs(inputs)
+	req, err := loadSgCertificatePostBody(req, d, inputs)
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -195,6 +231,7 @@ func resourceSecretGroupCertificateRead(ctx context.Context, d *schema.ResourceD
 		return diags
 	}
 
+	InitFileInputDigests(d, "certificate")
 	d.SetId(id)
 	d.Set("sg_id", sgid)
 	d.Set("env_id", envid)
@@ -215,7 +252,9 @@ func resourceSecretGroupCertificateUpdate(ctx context.Context, d *schema.Resourc
 		authctx := getSgCertificateAuthCtx(ctx, &pco)
 		//prepare request
 		req := pco.sgcertificateclient.DefaultApi.PutSecretGroupCertificate(authctx, orgid, envid, sgid, id).AllowExpiredCert(allow_expired_cert)
-		req, err := loadSgCertificatePutBody(req, d)
+		inputs := &FileInputs{}
+		defer CloseFileInputs(inputs)
+		req, err := loadSgCertificatePutBody(req, d, inputs)
 		if err != nil {
 			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Error,
@@ -259,7 +298,7 @@ func resourceSecretGroupCertificateDelete(ctx context.Context, d *schema.Resourc
 	return diags
 }
 
-func loadSgCertificatePostBody(req secretgroup_certificate.DefaultApiPostSecretGroupCertificateRequest, d *schema.ResourceData) (secretgroup_certificate.DefaultApiPostSecretGroupCertificateRequest, error) {
+func loadSgCertificatePostBody(req secretgroup_certificate.DefaultApiPostSecretGroupCertificateRequest, d resourceDataGetter, inputs *FileInputs) (secretgroup_certificate.DefaultApiPostSecretGroupCertificateRequest, error) {
 	// if val, ok := d.GetOk("expiration_date"); ok {
 	// 	req = req.ExpirationDate(val.(string))
 	// }
@@ -269,17 +308,15 @@ func loadSgCertificatePostBody(req secretgroup_certificate.DefaultApiPostSecretG
 	if val, ok := d.GetOk("type"); ok {
 		req = req.Type_(val.(string))
 	}
-	if val, ok := d.GetOk("certificate"); ok {
-		file, err := os.Open(val.(string))
-		if err != nil {
-			return req, err
-		}
+	if file, ok, err := OpenFileInput(d, "certificate", "certificate-*.pem", inputs); err != nil {
+		return req, err
+	} else if ok {
 		req = req.CertStore(file)
 	}
 	return req, nil
 }
 
-func loadSgCertificatePutBody(req secretgroup_certificate.DefaultApiPutSecretGroupCertificateRequest, d *schema.ResourceData) (secretgroup_certificate.DefaultApiPutSecretGroupCertificateRequest, error) {
+func loadSgCertificatePutBody(req secretgroup_certificate.DefaultApiPutSecretGroupCertificateRequest, d *schema.ResourceData, inputs *FileInputs) (secretgroup_certificate.DefaultApiPutSecretGroupCertificateRequest, error) {
 	// if val, ok := d.GetOk("expiration_date"); ok {
 	// 	req = req.ExpirationDate(val.(string))
 	// }
@@ -289,11 +326,9 @@ func loadSgCertificatePutBody(req secretgroup_certificate.DefaultApiPutSecretGro
 	if val, ok := d.GetOk("type"); ok {
 		req = req.Type_(val.(string))
 	}
-	if val, ok := d.GetOk("certificate"); ok {
-		file, err := os.Open(val.(string))
-		if err != nil {
-			return req, err
-		}
+	if file, ok, err := OpenFileInput(d, "certificate", "certificate-*.pem", inputs); err != nil {
+		return req, err
+	} else if ok {
 		req = req.CertStore(file)
 	}
 	return req, nil
@@ -307,7 +342,7 @@ func decomposeSgCertificateId(d *schema.ResourceData) (string, string, string, s
 
 func getSgCertificateUpdatableAttributes() []string {
 	attributes := [...]string{
-		"allow_expired_cert", "name", "type", "certificate",
+		"allow_expired_cert", "name", "type", "certificate", "certificate_digest",
 	}
 	return attributes[:]
 }
@@ -316,6 +351,6 @@ func getSgCertificateUpdatableAttributes() []string {
  * Returns authentication context (includes authorization header)
  */
 func getSgCertificateAuthCtx(ctx context.Context, pco *ProviderConfOutput) context.Context {
-	tmp := context.WithValue(ctx, secretgroup_certificate.ContextAccessToken, pco.access_token)
+	tmp := context.WithValue(ctx, secretgroup_certificate.ContextAccessToken, pco.tokensource.Token(ctx))
 	return context.WithValue(tmp, secretgroup_certificate.ContextServerIndex, pco.server_index)
 }
@@ -0,0 +1,648 @@
+package anypoint
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mulesoft-anypoint/anypoint-client-go/secretgroup"
+)
+
+const (
+	SG_CONTENT_KEYSTORES       = "keystores"
+	SG_CONTENT_TRUSTSTORES     = "truststores"
+	SG_CONTENT_CERTIFICATES    = "certificates"
+	SG_CONTENT_CRL_DISTRIB_CFG = "crlDistributorConfigs"
+	SG_CONTENT_TLS_CONTEXTS    = "tlsContexts"
+)
+
+// secret group's contents attributes that are not copied
+var SG_COPY_IGNORED_ATTRIBUTES = []string{"meta", "expirationDate", "details"}
+
+func resourceSecretGroupCopy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSecretGroupCopyCreate,
+		ReadContext:   resourceSecretGroupCopyRead,
+		DeleteContext: resourceSecretGroupCopyDelete,
+		CustomizeDiff: customizeDiffProviderDefaults,
+		Description: `
+		Copies a secret group from an environment to another one.
+		The structure of the source secret group is cloned: the tls contexts and crl distributor configs are copied and their references to the secrets are updated.
+		Secrets cannot be read from the source secret group, the keystores, truststores and certificates to copy have to be re-supplied as inputs using the same name as in the source secret group. Secrets that are not re-supplied are skipped, unless referenced by the copied tls contexts or crl distributor configs.
+		Any change recreates the copy. Deleting this resource deletes the target secret group.
+		`,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The id of the target secret group",
+			},
+			"org_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The organization id where the source and target secret groups are defined. Defaults to the provider's default_org_id.",
+			},
+			"source_env_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The environment id where the source secret group is defined.",
+			},
+			"source_sg_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The id of the secret group to copy.",
+			},
+			"target_env_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The environment id where the secret group is copied.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The name of the target secret group. Defaults to the name of the source secret group.",
+			},
+			"downloadable": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the secrets of the target secret group are downloadable, copied from the source secret group.",
+			},
+			"finalize": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				ForceNew:    true,
+				Description: "With 'true' to finalize the target secret group once copied.",
+			},
+			"keystore": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Keystores to copy. The type, alias and algorithm are copied from the source keystore.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+							Description: "The name of the keystore in the source secret group.",
+						},
+						"key_content": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							ForceNew:    true,
+							Description: "The content of the private key in PEM format. Required in case of PEM type.",
+						},
+						"key_passphrase": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							ForceNew:    true,
+							Description: "Passphrase with which private key is protected.",
+						},
+						"certificate_content": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "The content of the certificate in PEM format. Required in case of PEM type.",
+						},
+						"capath_content": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "The content of the CA chain in PEM format.",
+						},
+						"keystore_base64": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							ForceNew:    true,
+							Description: "The base64 encoded content of the keystore. Required in case of JKS, JCEKS and PKCS12 types.",
+						},
+						"store_passphrase": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							ForceNew:    true,
+							Description: "Passphrase with which keystore is protected. Required in case of JKS, JCEKS and PKCS12 types.",
+						},
+					},
+				},
+			},
+			"truststore": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Truststores to copy. The type and algorithm are copied from the source truststore.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+							Description: "The name of the truststore in the source secret group.",
+						},
+						"truststore_content": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "The content of the truststore in PEM format.",
+						},
+						"truststore_base64": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							ForceNew:    true,
+							Description: "The base64 encoded content of the truststore, for binary types (JKS, JCEKS and PKCS12).",
+						},
+						"store_passphrase": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							ForceNew:    true,
+							Description: "The passphrase with which the truststore is protected. Required in case of JKS, JCEKS and PKCS12 types.",
+						},
+					},
+				},
+			},
+			"certificate": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Certificates to copy. The type is copied from the source certificate.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+							Description: "The name of the certificate in the source secret group.",
+						},
+						"certificate_content": {
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+							Description: "The content of the certificate in PEM format.",
+						},
+					},
+				},
+			},
+			"keystore_ids": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The ids of the keystores of the target secret group by name.",
+			},
+			"truststore_ids": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The ids of the truststores of the target secret group by name.",
+			},
+			"certificate_ids": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The ids of the certificates of the target secret group by name.",
+			},
+			"crldistrib_cfgs_ids": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The ids of the crl distributor configs of the target secret group by name.",
+			},
+			"tlscontext_ids": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The ids of the tls contexts of the target secret group by name.",
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(SG_LOCK_DEFAULT_TIMEOUT),
+			Delete: schema.DefaultTimeout(SG_LOCK_DEFAULT_TIMEOUT),
+		},
+	}
+}
+
+func resourceSecretGroupCopyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid := d.Get("org_id").(string)
+	srcenvid := d.Get("source_env_id").(string)
+	srcsgid := d.Get("source_sg_id").(string)
+	envid := d.Get("target_env_id").(string)
+	authctx := getSecretGroupAuthCtx(ctx, &pco)
+	//read source secret group
+	src, httpr, err := pco.secretgroupclient.DefaultApi.GetSecretGroup(authctx, orgid, srcenvid, srcsgid).Execute()
+	if err != nil {
+		var details string
+		if httpr != nil && httpr.StatusCode >= 400 {
+			defer httpr.Body.Close()
+			b, _ := io.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to get source secret group " + srcsgid,
+			Detail:   details,
+		})
+		return diags
+	}
+	httpr.Body.Close()
+	//create target secret group
+	body := secretgroup.NewSecretGroupPostBody()
+	body.SetName(src.GetName())
+	if val, ok := d.GetOk("name"); ok {
+		body.SetName(val.(string))
+	}
+	body.SetDownloadable(src.GetDownloadable())
+	res, httpr, err := pco.secretgroupclient.DefaultApi.PostSecretGroup(authctx, orgid, envid).SecretGroupPostBody(*body).Execute()
+	if err != nil {
+		var details string
+		if httpr != nil && httpr.StatusCode >= 400 {
+			defer httpr.Body.Close()
+			b, _ := io.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to create target secret group for org " + orgid + " and env " + envid,
+			Detail:   details,
+		})
+		return diags
+	}
+	defer httpr.Body.Close()
+	sgid := res.GetId()
+	d.SetId(sgid)
+	//copy contents
+	if err := copySecretGroupContents(ctx, &pco, d, orgid, srcenvid, srcsgid, envid, sgid); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to copy secret group " + srcsgid + " to " + sgid,
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	if d.Get("finalize").(bool) {
+		if err := finalizeSecretGroup(ctx, &pco, orgid, envid, sgid, d.Timeout(schema.TimeoutCreate)); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to finalize secret group " + sgid,
+				Detail:   err.Error(),
+			})
+			return diags
+		}
+	}
+
+	return resourceSecretGroupCopyRead(ctx, d, m)
+}
+
+func resourceSecretGroupCopyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid := d.Get("org_id").(string)
+	envid := d.Get("target_env_id").(string)
+	sgid := d.Id()
+	authctx := getSecretGroupAuthCtx(ctx, &pco)
+	res, httpr, err := pco.secretgroupclient.DefaultApi.GetSecretGroup(authctx, orgid, envid, sgid).Execute()
+	if err != nil {
+		var details string
+		if httpr != nil && httpr.StatusCode >= 400 {
+			defer httpr.Body.Close()
+			b, _ := io.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to get secret group " + sgid,
+			Detail:   details,
+		})
+		return diags
+	}
+	defer httpr.Body.Close()
+	d.Set("name", res.GetName())
+	d.Set("downloadable", res.GetDownloadable())
+	//read contents ids
+	attributes := map[string]string{
+		"keystore_ids":        SG_CONTENT_KEYSTORES,
+		"truststore_ids":      SG_CONTENT_TRUSTSTORES,
+		"certificate_ids":     SG_CONTENT_CERTIFICATES,
+		"crldistrib_cfgs_ids": SG_CONTENT_CRL_DISTRIB_CFG,
+		"tlscontext_ids":      SG_CONTENT_TLS_CONTEXTS,
+	}
+	for attr, kind := range attributes {
+		list, err := listSgContents(ctx, &pco, orgid, envid, sgid, kind)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to list " + kind + " of secret group " + sgid,
+				Detail:   err.Error(),
+			})
+			return diags
+		}
+		ids := make(map[string]interface{})
+		for _, item := range list {
+			name, _ := item["name"].(string)
+			ids[name] = getSgContentId(item)
+		}
+		if err := d.Set(attr, ids); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to set " + attr + " of secret group " + sgid,
+				Detail:   err.Error(),
+			})
+			return diags
+		}
+	}
+
+	return diags
+}
+
+func resourceSecretGroupCopyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid := d.Get("org_id").(string)
+	envid := d.Get("target_env_id").(string)
+	sgid := d.Id()
+	if _, err := waitSecretGroupClear(ctx, &pco, orgid, envid, sgid, d.Timeout(schema.TimeoutDelete), false, false); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to delete secret group " + sgid,
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	authctx := getSecretGroupAuthCtx(ctx, &pco)
+	httpr, err := pco.secretgroupclient.DefaultApi.DeleteSecretGroup(authctx, orgid, envid, sgid).Execute()
+	if err != nil {
+		var details string
+		if httpr != nil && httpr.StatusCode >= 400 {
+			defer httpr.Body.Close()
+			b, _ := io.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to delete secret group " + sgid,
+			Detail:   details,
+		})
+		return diags
+	}
+	defer httpr.Body.Close()
+	d.SetId("")
+	return diags
+}
+
+// copies the secrets given as inputs, the crl distributor configs and the tls contexts of the source secret group to the target one.
+// the references to the secrets are updated with the ids of the copied secrets.
+func copySecretGroupContents(ctx context.Context, pco *ProviderConfOutput, d *schema.ResourceData, orgid, srcenvid, srcsgid, envid, sgid string) error {
+	// source secret path -> target secret path
+	paths := make(map[string]string)
+	//secrets
+	for _, kind := range []string{SG_CONTENT_KEYSTORES, SG_CONTENT_TRUSTSTORES, SG_CONTENT_CERTIFICATES} {
+		inputs := getSgCopySecretInputs(d, kind)
+		list, err := listSgContents(ctx, pco, orgid, srcenvid, srcsgid, kind)
+		if err != nil {
+			return err
+		}
+		for _, item := range list {
+			name, _ := item["name"].(string)
+			input, ok := inputs[name]
+			if !ok {
+				continue
+			}
+			delete(inputs, name)
+			srcid := getSgContentId(item)
+			details, err := getSgContent(ctx, pco, orgid, srcenvid, srcsgid, kind, srcid)
+			if err != nil {
+				return err
+			}
+			id, err := postSgCopySecret(ctx, pco, orgid, envid, sgid, kind, details, input)
+			if err != nil {
+				return fmt.Errorf("unable to copy %s %s. %s", kind, name, err.Error())
+			}
+			paths[kind+"/"+srcid] = kind + "/" + id
+		}
+		for name := range inputs {
+			return fmt.Errorf("%s %s not found in source secret group %s", kind, name, srcsgid)
+		}
+	}
+	//structure
+	for _, kind := range []string{SG_CONTENT_CRL_DISTRIB_CFG, SG_CONTENT_TLS_CONTEXTS} {
+		list, err := listSgContents(ctx, pco, orgid, srcenvid, srcsgid, kind)
+		if err != nil {
+			return err
+		}
+		for _, item := range list {
+			name, _ := item["name"].(string)
+			srcid := getSgContentId(item)
+			details, err := getSgContent(ctx, pco, orgid, srcenvid, srcsgid, kind, srcid)
+			if err != nil {
+				return err
+			}
+			body, err := newSgCopyContentBody(details, paths)
+			if err != nil {
+				return fmt.Errorf("unable to copy %s %s. %s", kind, name, err.Error())
+			}
+			var res map[string]interface{}
+			httpr, err := pco.secretsmanagerclient.Execute(getSecretsManagerAuthCtx(ctx, pco), http.MethodPost, getSgContentsPath(orgid, envid, sgid, kind), body, &res)
+			if err != nil {
+				return fmt.Errorf("unable to copy %s %s. %s", kind, name, getHttpErrorDetails(httpr, err))
+			}
+			httpr.Body.Close()
+			id, _ := res["id"].(string)
+			paths[kind+"/"+srcid] = kind + "/" + id
+		}
+	}
+	return nil
+}
+
+// creates the given secret in the target secret group using the source secret details (type, alias, algorithm) and the re-supplied input
+// returns the id of the created secret
+func postSgCopySecret(ctx context.Context, pco *ProviderConfOutput, orgid, envid, sgid, kind string, details map[string]interface{}, input map[string]interface{}) (string, error) {
+	data := mapResourceDataGetter{}
+	for k, v := range input {
+		data[k] = v
+	}
+	for _, attr := range []string{"type", "alias", "algorithm"} {
+		if val, ok := details[attr]; ok {
+			data[attr] = val
+		}
+	}
+	var id string
+	var httpr *http.Response
+	var err error
+	inputs := &FileInputs{}
+	defer CloseFileInputs(inputs)
+	switch kind {
+	case SG_CONTENT_KEYSTORES:
+		req := pco.sgkeystoreclient.DefaultApi.PostSecretGroupKeystores(getSgKeystoreAuthCtx(ctx, pco), orgid, envid, sgid)
+		if req, err = loadSgKeystorePostBody(req, data, inputs); err != nil {
+			return "", err
+		}
+		res, r, e := req.Execute()
+		httpr, err = r, e
+		if e == nil {
+			id = res.GetId()
+		}
+	case SG_CONTENT_TRUSTSTORES:
+		req := pco.sgtruststoreclient.DefaultApi.PostSecretGroupTruststore(getSgTruststoreAuthCtx(ctx, pco), orgid, envid, sgid)
+		if req, err = loadSgTruststorePostBody(req, data, inputs); err != nil {
+			return "", err
+		}
+		res, r, e := req.Execute()
+		httpr, err = r, e
+		if e == nil {
+			id = res.GetId()
+		}
+	case SG_CONTENT_CERTIFICATES:
+		req := pco.sgcertificateclient.DefaultApi.PostSecretGroupCertificate(getSgCertificateAuthCtx(ctx, pco), orgid, envid, sgid)
+		if req, err = loadSgCertificatePostBody(req, data, inputs); err != nil {
+			return "", err
+		}
+		res, r, e := req.Execute()
+		httpr, err = r, e
+		if e == nil {
+			id = res.GetId()
+		}
+	default:
+		return "", fmt.Errorf("unsupported secret kind %s", kind)
+	}
+	if err != nil {
+		return "", fmt.Errorf("%s", getHttpErrorDetails(httpr, err))
+	}
+	httpr.Body.Close()
+	return id, nil
+}
+
+// returns the re-supplied secrets of the given kind by name
+func getSgCopySecretInputs(d *schema.ResourceData, kind string) map[string]map[string]interface{} {
+	attributes := map[string]string{
+		SG_CONTENT_KEYSTORES:    "keystore",
+		SG_CONTENT_TRUSTSTORES:  "truststore",
+		SG_CONTENT_CERTIFICATES: "certificate",
+	}
+	inputs := make(map[string]map[string]interface{})
+	for _, item := range d.Get(attributes[kind]).([]interface{}) {
+		if item == nil {
+			continue
+		}
+		input := item.(map[string]interface{})
+		inputs[input["name"].(string)] = input
+	}
+	return inputs
+}
+
+// prepares the body to copy the given content, removes the read only attributes and updates the references to the secrets
+func newSgCopyContentBody(details map[string]interface{}, paths map[string]string) (map[string]interface{}, error) {
+	body := make(map[string]interface{})
+	for k, v := range details {
+		if StringInSlice(SG_COPY_IGNORED_ATTRIBUTES, k, false) {
+			continue
+		}
+		val, err := replaceSgCopySecretPaths(v, paths)
+		if err != nil {
+			return nil, err
+		}
+		body[k] = val
+	}
+	return body, nil
+}
+
+// walks through the given value and replaces the secret paths (i.e. {"path": "keystores/<id>"}) using the given paths
+func replaceSgCopySecretPaths(value interface{}, paths map[string]string) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{})
+		for key, item := range v {
+			if path, ok := item.(string); ok && key == "path" && strings.Contains(path, "/") {
+				target, found := paths[path]
+				if !found {
+					return nil, fmt.Errorf("the secret %s is referenced but has not been copied, it should be provided as input", path)
+				}
+				result[key] = target
+				continue
+			}
+			val, err := replaceSgCopySecretPaths(item, paths)
+			if err != nil {
+				return nil, err
+			}
+			result[key] = val
+		}
+		return result, nil
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			val, err := replaceSgCopySecretPaths(item, paths)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = val
+		}
+		return result, nil
+	default:
+		return value, nil
+	}
+}
+
+// lists the contents of the given kind (keystores, truststores, tlsContexts...) of a secret group
+func listSgContents(ctx context.Context, pco *ProviderConfOutput, orgid, envid, sgid, kind string) ([]map[string]interface{}, error) {
+	var res []map[string]interface{}
+	httpr, err := pco.secretsmanagerclient.Execute(getSecretsManagerAuthCtx(ctx, pco), http.MethodGet, getSgContentsPath(orgid, envid, sgid, kind), nil, &res)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list %s of secret group %s. %s", kind, sgid, getHttpErrorDetails(httpr, err))
+	}
+	httpr.Body.Close()
+	return res, nil
+}
+
+// reads the details of a secret group's content of the given kind
+func getSgContent(ctx context.Context, pco *ProviderConfOutput, orgid, envid, sgid, kind, id string) (map[string]interface{}, error) {
+	var res map[string]interface{}
+	path := getSgContentsPath(orgid, envid, sgid, kind) + "/" + url.PathEscape(id)
+	httpr, err := pco.secretsmanagerclient.Execute(getSecretsManagerAuthCtx(ctx, pco), http.MethodGet, path, nil, &res)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get %s %s of secret group %s. %s", kind, id, sgid, getHttpErrorDetails(httpr, err))
+	}
+	httpr.Body.Close()
+	return res, nil
+}
+
+// returns the id of a secret group's content from its meta data
+func getSgContentId(item map[string]interface{}) string {
+	if meta, ok := item["meta"].(map[string]interface{}); ok {
+		if id, ok := meta["id"].(string); ok {
+			return id
+		}
+	}
+	return ""
+}
+
+func getSgContentsPath(orgid, envid, sgid, kind string) string {
+	return fmt.Sprintf(
+		"/organizations/%s/environments/%s/secretGroups/%s/%s",
+		url.PathEscape(orgid), url.PathEscape(envid), url.PathEscape(sgid), kind,
+	)
+}
@@ -17,6 +17,7 @@ func resourceSecretGroupCrlDistribCfgs() *schema.Resource {
 		ReadContext:   resourceSecretGroupCrlDistribCfgsRead,
 		UpdateContext: resourceSecretGroupCrlDistribCfgsUpdate,
 		DeleteContext: resourceSecretGroupCrlDistribCfgsDelete,
+		CustomizeDiff: customizeDiffProviderDefaults,
 		Description: `
 		Create and manage crl-distributor-configs for a secret-group in a given organization and environment.
 		This resource doesn't support delete. The delete operation only removes the resource from local terraform state file.
@@ -41,15 +42,17 @@ func resourceSecretGroupCrlDistribCfgs() *schema.Resource {
 			},
 			"org_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The organization id where the crl-distributor-configs's secret group is defined.",
+				Description: "The organization id where the crl-distributor-configs's secret group is defined. Defaults to the provider's default_org_id.",
 			},
 			"env_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The environment id where the crl-distributor-configs's secret group is defined.",
+				Description: "The environment id where the crl-distributor-configs's secret group is defined. Defaults to the provider's default_env_id or default_env_name.",
 			},
 			"name": {
 				Type:        schema.TypeString,
@@ -270,7 +273,7 @@ func getSgCrlDistribCfgsUpdatableAttributes() []string {
  * Returns authentication context (includes authorization header)
  */
 func getSgCrlDistribCfgsAuthCtx(ctx context.Context, pco *ProviderConfOutput) context.Context {
-	tmp := context.WithValue(ctx, secretgroup_crl_distributor_configs.ContextAccessToken, pco.access_token)
+	tmp := context.WithValue(ctx, secretgroup_crl_distributor_configs.ContextAccessToken, pco.tokensource.Token(ctx))
 	return context.WithValue(tmp, secretgroup_crl_distributor_configs.ContextServerIndex, pco.server_index)
 }
 
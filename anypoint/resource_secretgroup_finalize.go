@@ -0,0 +1,300 @@
+package anypoint
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mulesoft-anypoint/anypoint-client-go/secretgroup"
+)
+
+const (
+	SG_STATE_CLEAR      = "Clear"
+	SG_STATE_FINISHING  = "Finishing"
+	SG_STATE_CANCELLING = "Cancelling"
+	SG_STATE_DELETING   = "Deleting"
+	// not returned by the platform, used while the secret group is locked but clear
+	SG_STATE_LOCKED = "Locked"
+)
+
+const (
+	SG_LOCK_DEFAULT_TIMEOUT = 10 * time.Minute
+	SG_LOCK_POLL_INTERVAL   = 3 * time.Second
+)
+
+// user or connected app the provider is authenticated with
+type accountsMe struct {
+	User struct {
+		Username string `json:"username"`
+	} `json:"user"`
+	Client struct {
+		ClientId string `json:"client_id"`
+		Name     string `json:"name"`
+	} `json:"client"`
+}
+
+func resourceSecretGroupFinalize() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSecretGroupFinalizeCreate,
+		ReadContext:   resourceSecretGroupFinalizeRead,
+		DeleteContext: resourceSecretGroupFinalizeDelete,
+		CustomizeDiff: customizeDiffProviderDefaults,
+		Description: `
+		Finalizes a secret group once its contents (keystores, truststores, certificates, tls contexts...) are populated.
+		The secret group is locked then the lock is released with the action 'finish', the resource waits until the secret group is back to the 'Clear' state.
+		A lock already held by the provider's own user or connected app is reused. A lock held by someone else is never finished: the resource waits for it to be released and fails with the name of the lock holder on timeout.
+		Use depends_on on the secret group's contents and the triggers to finalize the secret group again when they change.
+		The delete operation only removes the resource from local terraform state file.
+		`,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The id of the finalized secret group",
+			},
+			"org_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The organization id where the secret group is defined. Defaults to the provider's default_org_id.",
+			},
+			"env_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The environment id where the secret group is defined. Defaults to the provider's default_env_id or default_env_name.",
+			},
+			"sg_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The id of the secret group to finalize.",
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary map of values that, when changed, will finalize the secret group again. i.e. the ids or digests of the secret group's contents.",
+			},
+			"last_finalized": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The last time the secret group has been finalized.",
+			},
+			"locked": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Indicates whether the secret group is currently locked",
+			},
+			"locked_by": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Username of the anypoint platform user who currently holds the lock on the secret group.",
+			},
+			"current_state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The state the secret group is currently in.",
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(SG_LOCK_DEFAULT_TIMEOUT),
+		},
+	}
+}
+
+func resourceSecretGroupFinalizeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid := d.Get("org_id").(string)
+	envid := d.Get("env_id").(string)
+	sgid := d.Get("sg_id").(string)
+	if err := finalizeSecretGroup(ctx, &pco, orgid, envid, sgid, d.Timeout(schema.TimeoutCreate)); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to finalize secret group " + sgid,
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	d.SetId(sgid)
+	d.Set("last_finalized", time.Now().Format(time.RFC850))
+	return resourceSecretGroupFinalizeRead(ctx, d, m)
+}
+
+func resourceSecretGroupFinalizeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid := d.Get("org_id").(string)
+	envid := d.Get("env_id").(string)
+	sgid := d.Get("sg_id").(string)
+	authctx := getSecretGroupAuthCtx(ctx, &pco)
+	res, httpr, err := pco.secretgroupclient.DefaultApi.GetSecretGroup(authctx, orgid, envid, sgid).Execute()
+	if err != nil {
+		var details string
+		if httpr != nil && httpr.StatusCode >= 400 {
+			defer httpr.Body.Close()
+			b, _ := io.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to get secret group " + sgid,
+			Detail:   details,
+		})
+		return diags
+	}
+	defer httpr.Body.Close()
+	meta := res.GetMeta()
+	d.Set("locked", meta.GetLocked())
+	d.Set("locked_by", meta.GetLockedBy())
+	d.Set("current_state", meta.GetCurrentState())
+	return diags
+}
+
+func resourceSecretGroupFinalizeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	// NOTE: a finalization cannot be reverted
+	// Therefore we are only removing reference here
+	d.SetId("")
+	return diags
+}
+
+// locks the secret group then releases the lock with the action finish.
+// only a lock held by the provider's own principal is reused, the lock of someone else is waited for.
+// waits until the secret group is clear. the timeout bounds the whole finalization, not each wait
+func finalizeSecretGroup(ctx context.Context, pco *ProviderConfOutput, orgid, envid, sgid string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	sg, err := waitSecretGroupClear(ctx, pco, orgid, envid, sgid, time.Until(deadline), false, true)
+	if err != nil {
+		return err
+	}
+	meta := sg.GetMeta()
+	locked := meta.GetLocked()
+	if locked {
+		own, err := isOwnSecretGroupLock(ctx, pco, meta.GetLockedBy())
+		if err != nil {
+			return err
+		}
+		if !own {
+			// the edits of the lock holder are not ours to finish, wait until the lock is released
+			if _, err := waitSecretGroupClear(ctx, pco, orgid, envid, sgid, time.Until(deadline), false, false); err != nil {
+				return err
+			}
+			locked = false
+		}
+	}
+	authctx := getSecretsManagerAuthCtx(ctx, pco)
+	if !locked {
+		httpr, err := pco.secretsmanagerclient.Execute(authctx, http.MethodPost, getSgLockPath(orgid, envid, sgid), nil, nil)
+		if err != nil {
+			return fmt.Errorf("unable to lock secret group %s. %s", sgid, getHttpErrorDetails(httpr, err))
+		}
+		httpr.Body.Close()
+	}
+	if httpr, err := unlockSecretGroup(ctx, pco, orgid, envid, sgid, "finish"); err != nil {
+		return fmt.Errorf("unable to finish the lock of secret group %s. %s", sgid, getHttpErrorDetails(httpr, err))
+	}
+	_, err = waitSecretGroupClear(ctx, pco, orgid, envid, sgid, time.Until(deadline), false, false)
+	return err
+}
+
+// returns true if the secret group lock is held by the user or connected app the provider is authenticated with
+func isOwnSecretGroupLock(ctx context.Context, pco *ProviderConfOutput, lockedby string) (bool, error) {
+	var res accountsMe
+	authctx := getAccountsAuthCtx(ctx, pco)
+	httpr, err := pco.accountsclient.Execute(authctx, http.MethodGet, "/me", nil, &res)
+	if err != nil {
+		return false, fmt.Errorf("unable to get the current user. %s", getHttpErrorDetails(httpr, err))
+	}
+	httpr.Body.Close()
+	for _, principal := range []string{res.User.Username, res.Client.ClientId, res.Client.Name} {
+		if principal != "" && principal == lockedby {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// releases the lock of the secret group with the given action (finish or cancel)
+func unlockSecretGroup(ctx context.Context, pco *ProviderConfOutput, orgid, envid, sgid, action string) (*http.Response, error) {
+	authctx := getSecretsManagerAuthCtx(ctx, pco)
+	path := getSgLockPath(orgid, envid, sgid) + "?action=" + url.QueryEscape(action)
+	return pco.secretsmanagerclient.Execute(authctx, http.MethodDelete, path, nil, nil)
+}
+
+// waits until the secret group is in the 'Clear' state and, unless allowLocked is true, is not locked.
+// If forceUnlock is true, the lock held on a clear secret group is cancelled.
+func waitSecretGroupClear(ctx context.Context, pco *ProviderConfOutput, orgid, envid, sgid string, timeout time.Duration, forceUnlock bool, allowLocked bool) (*secretgroup.SecretGroup, error) {
+	target := []string{SG_STATE_CLEAR}
+	pending := []string{SG_STATE_FINISHING, SG_STATE_CANCELLING, SG_STATE_LOCKED}
+	if allowLocked {
+		target = append(target, SG_STATE_LOCKED)
+		pending = pending[:2]
+	}
+	deadline := time.Now().Add(timeout)
+	authctx := getSecretGroupAuthCtx(ctx, pco)
+	for {
+		sg, httpr, err := pco.secretgroupclient.DefaultApi.GetSecretGroup(authctx, orgid, envid, sgid).Execute()
+		if err != nil {
+			return nil, fmt.Errorf("unable to get secret group %s. %s", sgid, getHttpErrorDetails(httpr, err))
+		}
+		httpr.Body.Close()
+		state := getSecretGroupState(sg)
+		if StringInSlice(target, state, false) {
+			return sg, nil
+		}
+		meta := sg.GetMeta()
+		if !StringInSlice(pending, state, false) {
+			return nil, fmt.Errorf("secret group %s is in unexpected state %s", sgid, state)
+		}
+		if state == SG_STATE_LOCKED && forceUnlock {
+			if httpr, err := unlockSecretGroup(ctx, pco, orgid, envid, sgid, "cancel"); err != nil {
+				return nil, fmt.Errorf("unable to cancel the lock of secret group %s. %s", sgid, getHttpErrorDetails(httpr, err))
+			}
+			forceUnlock = false
+			continue
+		}
+		if time.Now().After(deadline) {
+			if state == SG_STATE_LOCKED {
+				return nil, fmt.Errorf("timeout while waiting for secret group %s to be unlocked, it is locked by '%s'", sgid, meta.GetLockedBy())
+			}
+			return nil, fmt.Errorf("timeout while waiting for secret group %s to be clear, current state is %s (locked by '%s')", sgid, state, meta.GetLockedBy())
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(SG_LOCK_POLL_INTERVAL):
+		}
+	}
+}
+
+// returns the current state of the secret group, 'Locked' if the secret group is clear but locked
+func getSecretGroupState(sg *secretgroup.SecretGroup) string {
+	meta := sg.GetMeta()
+	state := meta.GetCurrentState()
+	if state == "" {
+		state = SG_STATE_CLEAR
+	}
+	if state == SG_STATE_CLEAR && meta.GetLocked() {
+		return SG_STATE_LOCKED
+	}
+	return state
+}
+
+func getSgLockPath(orgid, envid, sgid string) string {
+	return fmt.Sprintf(
+		"/organizations/%s/environments/%s/secretGroups/%s/lock",
+		url.PathEscape(orgid), url.PathEscape(envid), url.PathEscape(sgid),
+	)
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"os"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -43,15 +42,17 @@ func resourceSecretGroupKeystore() *schema.Resource {
 			},
 			"org_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The organization id where the keystore's secret group is defined.",
+				Description: "The organization id where the keystore's secret group is defined. Defaults to the provider's default_org_id.",
 			},
 			"env_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The environment id where the keystore's secret group is defined.",
+				Description: "The environment id where the keystore's secret group is defined. Defaults to the provider's default_env_id or default_env_name.",
 			},
 			"allow_expired_cert": {
 				Type:        schema.TypeBool,
@@ -59,6 +60,12 @@ func resourceSecretGroupKeystore() *schema.Resource {
 				Default:     false,
 				Description: "With 'true' to allow uploading expired certificates",
 			},
+			"min_validity_days": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+				Description:      "When set, fails the plan if a certificate of the keystore to upload expires within the given number of days. Only checked for PEM keystores (certificate and capath).",
+			},
 			"name": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -81,9 +88,23 @@ func resourceSecretGroupKeystore() *schema.Resource {
 				),
 			},
 			"key": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ConflictsWith:    []string{"key_content"},
+				DiffSuppressFunc: DiffSuppressFunc4FilePath("key_digest"),
+				Description:      "The path to the encrypted private key. Required in case of PEM type unless key_content is provided. Moving or renaming the file with the same content doesn't cause a diff.",
+			},
+			"key_content": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"key"},
+				Description:   "The content of the encrypted private key in PEM format. Mutually exclusive with key.",
+			},
+			"key_digest": {
 				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "The path to the encrypted private key. Required in case of PEM type.",
+				Computed:    true,
+				Description: "The SHA-256 digest of the uploaded private key content. Used to detect changes of the key file or content.",
 			},
 			"key_passphrase": {
 				Type:        schema.TypeString,
@@ -91,16 +112,44 @@ func resourceSecretGroupKeystore() *schema.Resource {
 				Description: "Passphrase with which private key for a particular alias is protected.",
 			},
 			"certificate": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Sensitive:        true,
+				ConflictsWith:    []string{"certificate_content"},
+				DiffSuppressFunc: DiffSuppressFunc4FilePath("certificate_digest"),
+				Description:      "The path to the public certificate. Required in the case of PEM type unless certificate_content is provided. Moving or renaming the file with the same content doesn't cause a diff.",
+			},
+			"certificate_content": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"certificate"},
+				Description:   "The content of the public certificate in PEM format. Mutually exclusive with certificate.",
+			},
+			"certificate_digest": {
 				Type:        schema.TypeString,
-				Optional:    true,
-				Sensitive:   true,
-				Description: "The path to the public certificate. Required in the case of PEM type.",
+				Computed:    true,
+				Description: "The SHA-256 digest of the uploaded public certificate content. Used to detect changes of the certificate file or content.",
 			},
 			"capath": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Sensitive:        true,
+				ConflictsWith:    []string{"capath_content"},
+				DiffSuppressFunc: DiffSuppressFunc4FilePath("capath_digest"),
+				Description:      "The path to the concatenated chain of CA certificates, except the leaf, leading up to the root CA. Can only be set in case of PEM type. Moving or renaming the file with the same content doesn't cause a diff.",
+			},
+			"capath_content": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"capath"},
+				Description:   "The content of the concatenated chain of CA certificates in PEM format. Mutually exclusive with capath.",
+			},
+			"capath_digest": {
 				Type:        schema.TypeString,
-				Optional:    true,
-				Sensitive:   true,
-				Description: "The path to the concatenated chain of CA certificates, except the leaf, leading up to the root CA. Can only be set in case of PEM type.",
+				Computed:    true,
+				Description: "The SHA-256 digest of the uploaded CA path content. Used to detect changes of the capath file or content.",
 			},
 			"store_passphrase": {
 				Type:        schema.TypeString,
@@ -109,9 +158,23 @@ func resourceSecretGroupKeystore() *schema.Resource {
 				Description: "Passphrase with which keystore is protected. Required in case of JKS, JCEKS and PKCS12 types",
 			},
 			"keystore": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ConflictsWith:    []string{"keystore_base64"},
+				DiffSuppressFunc: DiffSuppressFunc4FilePath("keystore_digest"),
+				Description:      "The path to the file containing one or more certificate entries. Required in case of JKS, JCEKS and PKCS12 types unless keystore_base64 is provided. Moving or renaming the file with the same content doesn't cause a diff.",
+			},
+			"keystore_base64": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"keystore"},
+				Description:   "The base64 encoded content of the keystore (JKS, JCEKS or PKCS12). Mutually exclusive with keystore.",
+			},
+			"keystore_digest": {
 				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "The path to the file containing one or more certificate entries. Required in case of JKS, JCEKS and PKCS12 types",
+				Computed:    true,
+				Description: "The SHA-256 digest of the uploaded keystore content. Used to detect changes of the keystore file or content.",
 			},
 			"algorithm": {
 				Type:        schema.TypeString,
@@ -175,7 +238,19 @@ func resourceSecretGroupKeystore() *schema.Resource {
 			},
 		},
 		CustomizeDiff: func(ctx context.Context, rd *schema.ResourceDiff, i interface{}) error {
-			return validateKeystoreInput(rd)
+			if err := customizeDiffProviderDefaults(ctx, rd, i); err != nil {
+				return err
+			}
+			if err := validateKeystoreInput(rd); err != nil {
+				return err
+			}
+			if err := CustomizeDiff4FileInputDigests(rd, "key", "certificate", "capath", "keystore"); err != nil {
+				return err
+			}
+			if !isSgKeystorePEM(rd.Get("type").(string)) {
+				return nil
+			}
+			return CustomizeDiff4MinValidityDays(rd, "certificate", "capath")
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -194,7 +269,9 @@ func resourceSecretGroupKeystoreCreate(ctx context.Context, d *schema.ResourceDa
 	authctx := getSgKeystoreAuthCtx(ctx, &pco)
 	//prepare request
 	req := pco.sgkeystoreclient.DefaultApi.PostSecretGroupKeystores(authctx, orgid, envid, sgid).AllowExpiredCert(allow_expired_cert)
-	req, err := loadSgKeystorePostBody(req, d)
+	inputs := &FileInputs{}
+	defer CloseFileInputs(inputs)
+	req, err := loadSgKeystorePostBody(req, d, inputs)
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -266,6 +343,7 @@ func resourceSecretGroupKeystoreRead(ctx context.Context, d *schema.ResourceData
 		})
 		return diags
 	}
+	InitFileInputDigests(d, "key", "certificate", "capath", "keystore")
 	d.SetId(id)
 	d.Set("sg_id", sgid)
 	d.Set("env_id", envid)
@@ -293,7 +371,9 @@ func resourceSecretGroupKeystoreUpdate(ctx context.Context, d *schema.ResourceDa
 		authctx := getSgKeystoreAuthCtx(ctx, &pco)
 		//prepare request
 		req := pco.sgkeystoreclient.DefaultApi.PutSecretGroupKeystore(authctx, orgid, envid, sgid, id).AllowExpiredCert(allow_expired_cert)
-		req, err := loadSgKeystorePutBody(req, d)
+		inputs := &FileInputs{}
+		defer CloseFileInputs(inputs)
+		req, err := loadSgKeystorePutBody(req, d, inputs)
 		if err != nil {
 			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Error,
@@ -336,24 +416,22 @@ func resourceSecretGroupKeystoreDelete(ctx context.Context, d *schema.ResourceDa
 	return diags
 }
 
-func loadSgKeystorePostBody(req secretgroup_keystore.DefaultApiPostSecretGroupKeystoresRequest, d *schema.ResourceData) (secretgroup_keystore.DefaultApiPostSecretGroupKeystoresRequest, error) {
+func loadSgKeystorePostBody(req secretgroup_keystore.DefaultApiPostSecretGroupKeystoresRequest, d resourceDataGetter, inputs *FileInputs) (secretgroup_keystore.DefaultApiPostSecretGroupKeystoresRequest, error) {
 	t := d.Get("type").(string)
 	if isSgKeystorePEM(t) {
-		return loadSgKeystorePEMPostBody(req, d)
+		return loadSgKeystorePEMPostBody(req, d, inputs)
 	} else {
-		return loadSgKeystoreOthersPostBody(req, d)
+		return loadSgKeystoreOthersPostBody(req, d, inputs)
 	}
 }
 
-func loadSgKeystorePEMPostBody(req secretgroup_keystore.DefaultApiPostSecretGroupKeystoresRequest, d *schema.ResourceData) (secretgroup_keystore.DefaultApiPostSecretGroupKeystoresRequest, error) {
+func loadSgKeystorePEMPostBody(req secretgroup_keystore.DefaultApiPostSecretGroupKeystoresRequest, d resourceDataGetter, inputs *FileInputs) (secretgroup_keystore.DefaultApiPostSecretGroupKeystoresRequest, error) {
 	// if val, ok := d.GetOk("expiration_date"); ok {
 	// 	req = req.ExpirationDate(val.(string))
 	// }
-	if val, ok := d.GetOk("key"); ok {
-		file, err := os.Open(val.(string))
-		if err != nil {
-			return req, err
-		}
+	if file, ok, err := OpenFileInput(d, "key", "key-*.pem", inputs); err != nil {
+		return req, err
+	} else if ok {
 		req = req.Key(file)
 	}
 	if val, ok := d.GetOk("name"); ok {
@@ -362,36 +440,30 @@ func loadSgKeystorePEMPostBody(req secretgroup_keystore.DefaultApiPostSecretGrou
 	if val, ok := d.GetOk("key_passphrase"); ok {
 		req = req.KeyPassphrase(val.(string))
 	}
-	if val, ok := d.GetOk("certificate"); ok {
-		file, err := os.Open(val.(string))
-		if err != nil {
-			return req, err
-		}
+	if file, ok, err := OpenFileInput(d, "certificate", "certificate-*.pem", inputs); err != nil {
+		return req, err
+	} else if ok {
 		req = req.Certificate(file)
 	}
 	if val, ok := d.GetOk("type"); ok {
 		req = req.Type_(val.(string))
 	}
-	if val, ok := d.GetOk("capath"); ok {
-		file, err := os.Open(val.(string))
-		if err != nil {
-			return req, err
-		}
+	if file, ok, err := OpenFileInput(d, "capath", "capath-*.pem", inputs); err != nil {
+		return req, err
+	} else if ok {
 		req = req.Capath(file)
 	}
 
 	return req, nil
 }
 
-func loadSgKeystoreOthersPostBody(req secretgroup_keystore.DefaultApiPostSecretGroupKeystoresRequest, d *schema.ResourceData) (secretgroup_keystore.DefaultApiPostSecretGroupKeystoresRequest, error) {
+func loadSgKeystoreOthersPostBody(req secretgroup_keystore.DefaultApiPostSecretGroupKeystoresRequest, d resourceDataGetter, inputs *FileInputs) (secretgroup_keystore.DefaultApiPostSecretGroupKeystoresRequest, error) {
 	// if val, ok := d.GetOk("expiration_date"); ok {
 	// 	req = req.ExpirationDate(val.(string))
 	// }
-	if val, ok := d.GetOk("keystore"); ok {
-		file, err := os.Open(val.(string))
-		if err != nil {
-			return req, err
-		}
+	if file, ok, err := OpenFileInput(d, "keystore", "keystore-*"+getSgFileExtension(d.Get("type").(string)), inputs); err != nil {
+		return req, err
+	} else if ok {
 		req = req.KeyStore(file)
 	}
 	if val, ok := d.GetOk("name"); ok {
@@ -416,24 +488,22 @@ func loadSgKeystoreOthersPostBody(req secretgroup_keystore.DefaultApiPostSecretG
 	return req, nil
 }
 
-func loadSgKeystorePutBody(req secretgroup_keystore.DefaultApiPutSecretGroupKeystoreRequest, d *schema.ResourceData) (secretgroup_keystore.DefaultApiPutSecretGroupKeystoreRequest, error) {
+func loadSgKeystorePutBody(req secretgroup_keystore.DefaultApiPutSecretGroupKeystoreRequest, d *schema.ResourceData, inputs *FileInputs) (secretgroup_keystore.DefaultApiPutSecretGroupKeystoreRequest, error) {
 	t := d.Get("type").(string)
 	if isSgKeystorePEM(t) {
-		return loadSgKeystorePEMPutBody(req, d)
+		return loadSgKeystorePEMPutBody(req, d, inputs)
 	} else {
-		return loadSgKeystoreOthersPutBody(req, d)
+		return loadSgKeystoreOthersPutBody(req, d, inputs)
 	}
 }
 
-func loadSgKeystorePEMPutBody(req secretgroup_keystore.DefaultApiPutSecretGroupKeystoreRequest, d *schema.ResourceData) (secretgroup_keystore.DefaultApiPutSecretGroupKeystoreRequest, error) {
+func loadSgKeystorePEMPutBody(req secretgroup_keystore.DefaultApiPutSecretGroupKeystoreRequest, d *schema.ResourceData, inputs *FileInputs) (secretgroup_keystore.DefaultApiPutSecretGroupKeystoreRequest, error) {
 	// if val, ok := d.GetOk("expiration_date"); ok {
 	// 	req = req.ExpirationDate(val.(string))
 	// }
-	if val, ok := d.GetOk("key"); ok {
-		file, err := os.Open(val.(string))
-		if err != nil {
-			return req, err
-		}
+	if file, ok, err := OpenFileInput(d, "key", "key-*.pem", inputs); err != nil {
+		return req, err
+	} else if ok {
 		req = req.Key(file)
 	}
 	if val, ok := d.GetOk("name"); ok {
@@ -442,36 +512,30 @@ func loadSgKeystorePEMPutBody(req secretgroup_keystore.DefaultApiPutSecretGroupK
 	if val, ok := d.GetOk("key_passphrase"); ok {
 		req = req.KeyPassphrase(val.(string))
 	}
-	if val, ok := d.GetOk("certificate"); ok {
-		file, err := os.Open(val.(string))
-		if err != nil {
-			return req, err
-		}
+	if file, ok, err := OpenFileInput(d, "certificate", "certificate-*.pem", inputs); err != nil {
+		return req, err
+	} else if ok {
 		req = req.Certificate(file)
 	}
 	if val, ok := d.GetOk("type"); ok {
 		req = req.Type_(val.(string))
 	}
-	if val, ok := d.GetOk("capath"); ok {
-		file, err := os.Open(val.(string))
-		if err != nil {
-			return req, err
-		}
+	if file, ok, err := OpenFileInput(d, "capath", "capath-*.pem", inputs); err != nil {
+		return req, err
+	} else if ok {
 		req = req.Capath(file)
 	}
 
 	return req, nil
 }
 
-func loadSgKeystoreOthersPutBody(req secretgroup_keystore.DefaultApiPutSecretGroupKeystoreRequest, d *schema.ResourceData) (secretgroup_keystore.DefaultApiPutSecretGroupKeystoreRequest, error) {
+func loadSgKeystoreOthersPutBody(req secretgroup_keystore.DefaultApiPutSecretGroupKeystoreRequest, d *schema.ResourceData, inputs *FileInputs) (secretgroup_keystore.DefaultApiPutSecretGroupKeystoreRequest, error) {
 	// if val, ok := d.GetOk("expiration_date"); ok {
 	// 	req = req.ExpirationDate(val.(string))
 	// }
-	if val, ok := d.GetOk("keystore"); ok {
-		file, err := os.Open(val.(string))
-		if err != nil {
-			return req, err
-		}
+	if file, ok, err := OpenFileInput(d, "keystore", "keystore-*"+getSgFileExtension(d.Get("type").(string)), inputs); err != nil {
+		return req, err
+	} else if ok {
 		req = req.KeyStore(file)
 	}
 	if val, ok := d.GetOk("name"); ok {
@@ -506,14 +570,21 @@ func decomposeSgKeystoreId(d *schema.ResourceData) (string, string, string, stri
 func validateKeystoreInput(d *schema.ResourceDiff) error {
 	t := d.Get("type").(string)
 	var required []string
+	var files []string
 	if isSgKeystorePEM(t) {
-		required = []string{"key", "certificate"}
+		files = []string{"key", "certificate"}
 	} else {
-		required = []string{"keystore", "alias", "store_passphrase"}
+		files = []string{"keystore"}
+		required = []string{"alias", "store_passphrase"}
+	}
+	for _, f := range files {
+		if !IsFileInputSet(d, f) {
+			return fmt.Errorf("missing required attribute \"%s\" path (or its inline content) for keystore type %s", f, t)
+		}
 	}
 	for _, r := range required {
 		if _, ok := d.GetOk(r); !ok {
-			return fmt.Errorf("missing required attribute \"%s\" path for keystore type %s", r, t)
+			return fmt.Errorf("missing required attribute \"%s\" for keystore type %s", r, t)
 		}
 	}
 	return nil
@@ -521,15 +592,15 @@ func validateKeystoreInput(d *schema.ResourceDiff) error {
 
 func getSgKeystorePEMUpdatableAttributes() []string {
 	attributes := [...]string{
-		"allow_expired_cert", "name", "type", "key", "key_passphrase",
-		"certificate", "capath",
+		"allow_expired_cert", "name", "type", "key", "key_digest", "key_passphrase",
+		"certificate", "certificate_digest", "capath", "capath_digest",
 	}
 	return attributes[:]
 }
 
 func getSgKeystoreOthersUpdatableAttributes() []string {
 	attributes := [...]string{
-		"allow_expired_cert", "name", "type", "keystore", "key_passphrase",
+		"allow_expired_cert", "name", "type", "keystore", "keystore_digest", "key_passphrase",
 		"store_passphrase", "algorithm", "alias",
 	}
 	return attributes[:]
@@ -539,6 +610,6 @@ func getSgKeystoreOthersUpdatableAttributes() []string {
  * Returns authentication context (includes authorization header)
  */
 func getSgKeystoreAuthCtx(ctx context.Context, pco *ProviderConfOutput) context.Context {
-	tmp := context.WithValue(ctx, secretgroup_keystore.ContextAccessToken, pco.access_token)
+	tmp := context.WithValue(ctx, secretgroup_keystore.ContextAccessToken, pco.tokensource.Token(ctx))
 	return context.WithValue(tmp, secretgroup_keystore.ContextServerIndex, pco.server_index)
 }
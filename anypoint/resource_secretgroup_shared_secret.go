@@ -0,0 +1,513 @@
+package anypoint
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type sgSharedSecret struct {
+	Name            string              `json:"name"`
+	Type            string              `json:"type"`
+	ExpirationDate  string              `json:"expirationDate,omitempty"`
+	Username        string              `json:"username,omitempty"`
+	Password        string              `json:"password,omitempty"`
+	Key             string              `json:"key,omitempty"`
+	AccessKeyId     string              `json:"accessKeyId,omitempty"`
+	SecretAccessKey string              `json:"secretAccessKey,omitempty"`
+	Content         string              `json:"content,omitempty"`
+	Meta            *sgSharedSecretMeta `json:"meta,omitempty"`
+}
+
+type sgSharedSecretMeta struct {
+	Id   string `json:"id"`
+	Path string `json:"path"`
+}
+
+type sgSharedSecretPostResponse struct {
+	Id      string `json:"id"`
+	Message string `json:"message"`
+}
+
+// maps each shared secret type block to the corresponding shared secret type
+var SG_SHARED_SECRET_TYPES = map[string]string{
+	"username_password": "UsernamePassword",
+	"symmetric_key":     "SymmetricKey",
+	"s3_credential":     "S3Credential",
+	"blob":              "Blob",
+}
+
+var SG_SHARED_SECRET_TYPE_BLOCKS = []string{"username_password", "symmetric_key", "s3_credential", "blob"}
+
+var SG_SHARED_SECRET_DATE_REGEX = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+
+func resourceSecretGroupSharedSecret() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSecretGroupSharedSecretCreate,
+		ReadContext:   resourceSecretGroupSharedSecretRead,
+		UpdateContext: resourceSecretGroupSharedSecretUpdate,
+		DeleteContext: resourceSecretGroupSharedSecretDelete,
+		Description: `
+		Create and manage a shared secret for a given secret-group, organization and environment.
+		A shared secret is one of the following types: username and password, symmetric key, S3 credentials or blob. Use the corresponding block to define it.
+		Secret values are write-only: they are never returned by the platform, their state is kept from the configuration.
+		Changing the type of the shared secret replaces it: the shared secret is deleted from the secret-group before the new one is created.
+		`,
+		Schema: map[string]*schema.Schema{
+			"last_updated": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The last time this resource has been updated locally.",
+			},
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Id assigned to this shared secret",
+			},
+			"sg_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The secret-group id where the shared secret instance is defined.",
+			},
+			"org_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The organization id where the shared secret instance is defined. Defaults to the provider's default_org_id.",
+			},
+			"env_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The environment id where the shared secret instance is defined. Defaults to the provider's default_env_id or default_env_name.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the shared secret",
+			},
+			"expiration_date": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateDiagFunc: validation.ToDiagFunc(
+					validation.StringMatch(SG_SHARED_SECRET_DATE_REGEX, "expiration date should be in the format YYYY-MM-DD"),
+				),
+				Description: "The expiration date of the shared secret in the format YYYY-MM-DD",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The specific type of the shared secret, depends on the block used to define it. Either UsernamePassword, SymmetricKey, S3Credential or Blob",
+			},
+			"path": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "path of this secret, relative to the containing secret group",
+			},
+			"username_password": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: SG_SHARED_SECRET_TYPE_BLOCKS,
+				Description:  "Defines a shared secret of type UsernamePassword.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"username": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The username",
+						},
+						"password": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+							Description: "The password. This value is write-only.",
+						},
+					},
+				},
+			},
+			"symmetric_key": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: SG_SHARED_SECRET_TYPE_BLOCKS,
+				Description:  "Defines a shared secret of type SymmetricKey.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:      schema.TypeString,
+							Required:  true,
+							Sensitive: true,
+							ValidateDiagFunc: validation.ToDiagFunc(
+								validation.StringIsBase64,
+							),
+							Description: "The base64 encoded symmetric key. This value is write-only.",
+						},
+					},
+				},
+			},
+			"s3_credential": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: SG_SHARED_SECRET_TYPE_BLOCKS,
+				Description:  "Defines a shared secret of type S3Credential.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"access_key_id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The access key id",
+						},
+						"secret_access_key": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+							Description: "The secret access key. This value is write-only.",
+						},
+					},
+				},
+			},
+			"blob": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: SG_SHARED_SECRET_TYPE_BLOCKS,
+				Description:  "Defines a shared secret of type Blob.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"content": {
+							Type:      schema.TypeString,
+							Required:  true,
+							Sensitive: true,
+							ValidateDiagFunc: validation.ToDiagFunc(
+								validation.StringIsBase64,
+							),
+							Description: "The base64 encoded content of the blob. This value is write-only.",
+						},
+					},
+				},
+			},
+		},
+		CustomizeDiff: func(ctx context.Context, rd *schema.ResourceDiff, i interface{}) error {
+			if err := customizeDiffProviderDefaults(ctx, rd, i); err != nil {
+				return err
+			}
+			return customizeDiffSgSharedSecretType(rd)
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceSecretGroupSharedSecretCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid := d.Get("org_id").(string)
+	envid := d.Get("env_id").(string)
+	sgid := d.Get("sg_id").(string)
+	name := d.Get("name").(string)
+	authctx := getSecretsManagerAuthCtx(ctx, &pco)
+	//prepare request
+	body := newSgSharedSecretBody(d)
+	//perform request
+	var res sgSharedSecretPostResponse
+	httpr, err := pco.secretsmanagerclient.Execute(authctx, http.MethodPost, getSgSharedSecretsPath(orgid, envid, sgid), body, &res)
+	if err != nil {
+		var details string
+		if httpr != nil && httpr.StatusCode >= 400 {
+			defer httpr.Body.Close()
+			b, _ := io.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to create shared secret " + name,
+			Detail:   details,
+		})
+		return diags
+	}
+	defer httpr.Body.Close()
+
+	d.SetId(res.Id)
+
+	return resourceSecretGroupSharedSecretRead(ctx, d, m)
+}
+
+func resourceSecretGroupSharedSecretRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid := d.Get("org_id").(string)
+	envid := d.Get("env_id").(string)
+	sgid := d.Get("sg_id").(string)
+	id := d.Id()
+	if isComposedResourceId(id) {
+		orgid, envid, sgid, id = decomposeSgSharedSecretId(d)
+	}
+	authctx := getSecretsManagerAuthCtx(ctx, &pco)
+	//perform request
+	var res sgSharedSecret
+	httpr, err := pco.secretsmanagerclient.Execute(authctx, http.MethodGet, getSgSharedSecretPath(orgid, envid, sgid, id), nil, &res)
+	if err != nil {
+		var details string
+		if httpr != nil && httpr.StatusCode >= 400 {
+			defer httpr.Body.Close()
+			b, _ := io.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to read shared secret " + id,
+			Detail:   details,
+		})
+		return diags
+	}
+	defer httpr.Body.Close()
+	//process result
+	data := flattenSgSharedSecret(&res)
+	if err := setSgSharedSecretAttributesToResourceData(d, data, getSgSharedSecretResourceAttributes()); err != nil {
+		diags := append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to set shared secret " + id + " details attributes",
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	if err := setSgSharedSecretTypeBlockToResourceData(d, &res); err != nil {
+		diags := append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to set shared secret " + id + " details attributes",
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	d.SetId(id)
+	d.Set("sg_id", sgid)
+	d.Set("env_id", envid)
+	d.Set("org_id", orgid)
+
+	return diags
+}
+
+func resourceSecretGroupSharedSecretUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if d.HasChanges(getSgSharedSecretUpdatableAttributes()...) {
+		pco := m.(ProviderConfOutput)
+		orgid := d.Get("org_id").(string)
+		envid := d.Get("env_id").(string)
+		sgid := d.Get("sg_id").(string)
+		id := d.Id()
+		authctx := getSecretsManagerAuthCtx(ctx, &pco)
+		//prepare request
+		body := newSgSharedSecretBody(d)
+		//perform request
+		httpr, err := pco.secretsmanagerclient.Execute(authctx, http.MethodPut, getSgSharedSecretPath(orgid, envid, sgid, id), body, nil)
+		if err != nil {
+			var details string
+			if httpr != nil && httpr.StatusCode >= 400 {
+				defer httpr.Body.Close()
+				b, _ := io.ReadAll(httpr.Body)
+				details = string(b)
+			} else {
+				details = err.Error()
+			}
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to update shared secret " + id,
+				Detail:   details,
+			})
+			return diags
+		}
+		defer httpr.Body.Close()
+		d.Set("last_updated", time.Now().Format(time.RFC850))
+		return resourceSecretGroupSharedSecretRead(ctx, d, m)
+	}
+
+	return diags
+}
+
+func resourceSecretGroupSharedSecretDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid := d.Get("org_id").(string)
+	envid := d.Get("env_id").(string)
+	sgid := d.Get("sg_id").(string)
+	id := d.Id()
+	authctx := getSecretsManagerAuthCtx(ctx, &pco)
+	//perform request
+	httpr, err := pco.secretsmanagerclient.Execute(authctx, http.MethodDelete, getSgSharedSecretPath(orgid, envid, sgid, id), nil, nil)
+	if err != nil {
+		// the shared secret is already gone
+		if httpr != nil && httpr.StatusCode == http.StatusNotFound {
+			httpr.Body.Close()
+			d.SetId("")
+			return diags
+		}
+		var details string
+		if httpr != nil && httpr.StatusCode >= 400 {
+			defer httpr.Body.Close()
+			b, _ := io.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to delete shared secret " + id,
+			Detail:   details,
+		})
+		return diags
+	}
+	defer httpr.Body.Close()
+	// d.SetId("") is automatically called assuming delete returns no errors, but
+	// it is added here for explicitness.
+	d.SetId("")
+	return diags
+}
+
+// prepares the shared secret body depending on the type block that is defined
+func newSgSharedSecretBody(d *schema.ResourceData) *sgSharedSecret {
+	body := &sgSharedSecret{
+		Name: d.Get("name").(string),
+	}
+	if val, ok := d.GetOk("expiration_date"); ok {
+		body.ExpirationDate = val.(string)
+	}
+	block, t := getSgSharedSecretTypeBlock(d)
+	body.Type = t
+	if block == nil {
+		return body
+	}
+	switch t {
+	case SG_SHARED_SECRET_TYPES["username_password"]:
+		body.Username = block["username"].(string)
+		body.Password = block["password"].(string)
+	case SG_SHARED_SECRET_TYPES["symmetric_key"]:
+		body.Key = block["key"].(string)
+	case SG_SHARED_SECRET_TYPES["s3_credential"]:
+		body.AccessKeyId = block["access_key_id"].(string)
+		body.SecretAccessKey = block["secret_access_key"].(string)
+	case SG_SHARED_SECRET_TYPES["blob"]:
+		body.Content = block["content"].(string)
+	}
+	return body
+}
+
+// returns the defined type block along with its corresponding shared secret type
+func getSgSharedSecretTypeBlock(d resourceDataGetter) (map[string]interface{}, string) {
+	for _, attr := range SG_SHARED_SECRET_TYPE_BLOCKS {
+		if val, ok := d.GetOk(attr); ok {
+			l := val.([]interface{})
+			if len(l) > 0 && l[0] != nil {
+				return l[0].(map[string]interface{}), SG_SHARED_SECRET_TYPES[attr]
+			}
+			return nil, SG_SHARED_SECRET_TYPES[attr]
+		}
+	}
+	return nil, ""
+}
+
+// sets the shared secret type block from the remote data.
+// secret values are never returned by the platform, the ones from the state are preserved
+func setSgSharedSecretTypeBlockToResourceData(d *schema.ResourceData, res *sgSharedSecret) error {
+	current, _ := getSgSharedSecretTypeBlock(d)
+	getSecret := func(attr string) string {
+		if current == nil {
+			return ""
+		}
+		if val, ok := current[attr]; ok && val != nil {
+			return val.(string)
+		}
+		return ""
+	}
+	for _, attr := range SG_SHARED_SECRET_TYPE_BLOCKS {
+		var block []interface{}
+		if SG_SHARED_SECRET_TYPES[attr] == res.Type {
+			item := make(map[string]interface{})
+			switch attr {
+			case "username_password":
+				item["username"] = res.Username
+				item["password"] = getSecret("password")
+			case "symmetric_key":
+				item["key"] = getSecret("key")
+			case "s3_credential":
+				item["access_key_id"] = res.AccessKeyId
+				item["secret_access_key"] = getSecret("secret_access_key")
+			case "blob":
+				item["content"] = getSecret("content")
+			}
+			block = []interface{}{item}
+		}
+		if err := d.Set(attr, block); err != nil {
+			return fmt.Errorf("unable to set shared secret attribute %s\n\tdetails: %s", attr, err)
+		}
+	}
+	return nil
+}
+
+// computes the type of the shared secret from the defined type block, changing the type forces a new resource
+func customizeDiffSgSharedSecretType(rd *schema.ResourceDiff) error {
+	_, t := getSgSharedSecretTypeBlock(rd)
+	if t == "" || t == rd.Get("type").(string) {
+		return nil
+	}
+	if err := rd.SetNew("type", t); err != nil {
+		return err
+	}
+	if rd.Id() == "" {
+		return nil
+	}
+	for attr, val := range SG_SHARED_SECRET_TYPES {
+		if val == t && rd.HasChange(attr) {
+			return rd.ForceNew(attr)
+		}
+	}
+	return nil
+}
+
+func decomposeSgSharedSecretId(d *schema.ResourceData) (string, string, string, string) {
+	s := DecomposeResourceId(d.Id())
+	return s[0], s[1], s[2], s[3]
+}
+
+func getSgSharedSecretsPath(orgid, envid, sgid string) string {
+	return fmt.Sprintf(
+		"/organizations/%s/environments/%s/secretGroups/%s/sharedSecrets",
+		url.PathEscape(orgid), url.PathEscape(envid), url.PathEscape(sgid),
+	)
+}
+
+func getSgSharedSecretPath(orgid, envid, sgid, id string) string {
+	return getSgSharedSecretsPath(orgid, envid, sgid) + "/" + url.PathEscape(id)
+}
+
+// returns the attributes read from the platform, the type blocks are handled separately
+func getSgSharedSecretResourceAttributes() []string {
+	attributes := [...]string{
+		"name", "expiration_date", "type", "path",
+	}
+	return attributes[:]
+}
+
+func getSgSharedSecretUpdatableAttributes() []string {
+	attributes := [...]string{
+		"name", "expiration_date", "username_password", "symmetric_key", "s3_credential", "blob",
+	}
+	return attributes[:]
+}
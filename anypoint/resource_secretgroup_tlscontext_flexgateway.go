@@ -19,6 +19,7 @@ func resourceSecretGroupTlsContextFG() *schema.Resource {
 		ReadContext:   resourceSecretGroupTlsContextFGRead,
 		UpdateContext: resourceSecretGroupTlsContextFGUpdate,
 		DeleteContext: resourceSecretGroupTlsContextFGDelete,
+		CustomizeDiff: customizeDiffProviderDefaults,
 		Description: `
 		Create and Manage tls-context of type "FlexGateway" for a secret-group in a given organization and environment.
 		This resource doesn't support delete. The delete operation only removes the resource from local terraform state file.
@@ -43,15 +44,17 @@ func resourceSecretGroupTlsContextFG() *schema.Resource {
 			},
 			"org_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The organization id where the tls-context's secret group is defined.",
+				Description: "The organization id where the tls-context's secret group is defined. Defaults to the provider's default_org_id.",
 			},
 			"env_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The environment id where the tls-context's secret group is defined.",
+				Description: "The environment id where the tls-context's secret group is defined. Defaults to the provider's default_env_id or default_env_name.",
 			},
 			"path": {
 				Type:        schema.TypeString,
@@ -19,6 +19,7 @@ func resourceSecretGroupTlsContextMule() *schema.Resource {
 		ReadContext:   resourceSecretGroupTlsContextMuleRead,
 		UpdateContext: resourceSecretGroupTlsContextMuleUpdate,
 		DeleteContext: resourceSecretGroupTlsContextMuleDelete,
+		CustomizeDiff: customizeDiffProviderDefaults,
 		Description: `
 		Create and manage tls-context of type "Mule" for a secret-group in a given organization and environment.
 		This resource doesn't support delete. The delete operation only removes the resource from local terraform state file.
@@ -43,15 +44,17 @@ func resourceSecretGroupTlsContextMule() *schema.Resource {
 			},
 			"org_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The organization id where the tls-context's secret group is defined.",
+				Description: "The organization id where the tls-context's secret group is defined. Defaults to the provider's default_org_id.",
 			},
 			"env_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The environment id where the tls-context's secret group is defined.",
+				Description: "The environment id where the tls-context's secret group is defined. Defaults to the provider's default_env_id or default_env_name.",
 			},
 			"path": {
 				Type:        schema.TypeString,
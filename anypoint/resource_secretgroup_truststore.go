@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"os"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -43,15 +42,17 @@ func resourceSecretGroupTruststore() *schema.Resource {
 			},
 			"org_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The organization id where the truststore instance is defined.",
+				Description: "The organization id where the truststore instance is defined. Defaults to the provider's default_org_id.",
 			},
 			"env_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The environment id where the truststore instance is defined.",
+				Description: "The environment id where the truststore instance is defined. Defaults to the provider's default_env_id or default_env_name.",
 			},
 			"allow_expired_cert": {
 				Type:        schema.TypeBool,
@@ -59,6 +60,12 @@ func resourceSecretGroupTruststore() *schema.Resource {
 				Default:     false,
 				Description: "With 'true' to allow uploading expired certificates",
 			},
+			"min_validity_days": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+				Description:      "When set, fails the plan if a certificate of the truststore to upload expires within the given number of days. Only checked for PEM truststores.",
+			},
 			"name": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -81,9 +88,30 @@ func resourceSecretGroupTruststore() *schema.Resource {
 				),
 			},
 			"truststore": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ConflictsWith:    []string{"truststore_content", "truststore_base64"},
+				DiffSuppressFunc: DiffSuppressFunc4FilePath("truststore_digest"),
+				Description:      "Path to the file containing one or more trusted certificate entries. Moving or renaming the file with the same content doesn't cause a diff. Mutually exclusive with truststore_content and truststore_base64.",
+			},
+			"truststore_content": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"truststore", "truststore_base64"},
+				Description:   "The content of the truststore in PEM format. Mutually exclusive with truststore and truststore_base64.",
+			},
+			"truststore_base64": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"truststore", "truststore_content"},
+				Description:   "The base64 encoded content of the truststore, useful for binary types (JKS, JCEKS and PKCS12). Mutually exclusive with truststore and truststore_content.",
+			},
+			"truststore_digest": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Path to the file containing one or more trusted certificate entries",
+				Computed:    true,
+				Description: "The SHA-256 digest of the uploaded truststore content. Used to detect changes of the truststore file or content.",
 			},
 			"store_passphrase": {
 				Type:        schema.TypeString,
@@ -130,7 +158,19 @@ func resourceSecretGroupTruststore() *schema.Resource {
 			},
 		},
 		CustomizeDiff: func(ctx context.Context, rd *schema.ResourceDiff, i interface{}) error {
-			return validateTruststoreInput(rd)
+			if err := customizeDiffProviderDefaults(ctx, rd, i); err != nil {
+				return err
+			}
+			if err := validateTruststoreInput(rd); err != nil {
+				return err
+			}
+			if err := CustomizeDiff4FileInputDigests(rd, "truststore"); err != nil {
+				return err
+			}
+			if !isSgTruststorePEM(rd.Get("type").(string)) {
+				return nil
+			}
+			return CustomizeDiff4MinValidityDays(rd, "truststore")
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -149,7 +189,9 @@ func resourceSecretGroupTruststoreCreate(ctx context.Context, d *schema.Resource
 	authctx := getSgTruststoreAuthCtx(ctx, &pco)
 	//prepare request
 	req := pco.sgtruststoreclient.DefaultApi.PostSecretGroupTruststore(authctx, orgid, envid, sgid).AllowExpiredCert(allow_expired_cert)
-	req, err := loadSgTruststorePostBody(req, d)
+	inputs := &FileInputs{}
+	defer CloseFileInputs(inputs)
+	req, err := loadSgTruststorePostBody(req, d, inputs)
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
@@ -223,6 +265,7 @@ func resourceSecretGroupTruststoreRead(ctx context.Context, d *schema.ResourceDa
 		})
 		return diags
 	}
+	InitFileInputDigests(d, "truststore")
 	d.SetId(id)
 	d.Set("sg_id", sgid)
 	d.Set("env_id", envid)
@@ -249,7 +292,9 @@ func resourceSecretGroupTruststoreUpdate(ctx context.Context, d *schema.Resource
 		id := d.Get("id").(string)
 		authctx := getSgTruststoreAuthCtx(ctx, &pco)
 		req := pco.sgtruststoreclient.DefaultApi.PutSecretGroupTruststore(authctx, orgid, envid, sgid, id).AllowExpiredCert(allow_expired_cert)
-		req, err := loadSgTruststorePutBody(req, d)
+		inputs := &FileInputs{}
+		defer CloseFileInputs(inputs)
+		req, err := loadSgTruststorePutBody(req, d, inputs)
 		if err != nil {
 			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Error,
@@ -293,24 +338,22 @@ func resourceSecretGroupTruststoreDelete(ctx context.Context, d *schema.Resource
 	return diags
 }
 
-func loadSgTruststorePostBody(req secretgroup_truststore.DefaultApiPostSecretGroupTruststoreRequest, d *schema.ResourceData) (secretgroup_truststore.DefaultApiPostSecretGroupTruststoreRequest, error) {
+func loadSgTruststorePostBody(req secretgroup_truststore.DefaultApiPostSecretGroupTruststoreRequest, d resourceDataGetter, inputs *FileInputs) (secretgroup_truststore.DefaultApiPostSecretGroupTruststoreRequest, error) {
 	t := d.Get("type").(string)
 	if isSgTruststorePEM(t) {
-		return loadSgTruststorePEMPostBody(req, d)
+		return loadSgTruststorePEMPostBody(req, d, inputs)
 	} else {
-		return loadSgTruststoreOthersPostBody(req, d)
+		return loadSgTruststoreOthersPostBody(req, d, inputs)
 	}
 }
 
-func loadSgTruststorePEMPostBody(req secretgroup_truststore.DefaultApiPostSecretGroupTruststoreRequest, d *schema.ResourceData) (secretgroup_truststore.DefaultApiPostSecretGroupTruststoreRequest, error) {
+func loadSgTruststorePEMPostBody(req secretgroup_truststore.DefaultApiPostSecretGroupTruststoreRequest, d resourceDataGetter, inputs *FileInputs) (secretgroup_truststore.DefaultApiPostSecretGroupTruststoreRequest, error) {
 	// if val, ok := d.GetOk("expiration_date"); ok {
 	// 	req = req.ExpirationDate(val.(string))
 	// }
-	if val, ok := d.GetOk("truststore"); ok {
-		file, err := os.Open(val.(string))
-		if err != nil {
-			return req, err
-		}
+	if file, ok, err := OpenFileInput(d, "truststore", getSgTruststoreFilePattern(d), inputs); err != nil {
+		return req, err
+	} else if ok {
 		req = req.TrustStore(file)
 	}
 	if val, ok := d.GetOk("name"); ok {
@@ -323,7 +366,7 @@ func loadSgTruststorePEMPostBody(req secretgroup_truststore.DefaultApiPostSecret
 	return req, nil
 }
 
-func loadSgTruststoreOthersPostBody(req secretgroup_truststore.DefaultApiPostSecretGroupTruststoreRequest, d *schema.ResourceData) (secretgroup_truststore.DefaultApiPostSecretGroupTruststoreRequest, error) {
+func loadSgTruststoreOthersPostBody(req secretgroup_truststore.DefaultApiPostSecretGroupTruststoreRequest, d resourceDataGetter, inputs *FileInputs) (secretgroup_truststore.DefaultApiPostSecretGroupTruststoreRequest, error) {
 	// if val, ok := d.GetOk("expiration_date"); ok {
 	// 	req = req.ExpirationDate(val.(string))
 	// }
@@ -339,34 +382,30 @@ func loadSgTruststoreOthersPostBody(req secretgroup_truststore.DefaultApiPostSec
 	if val, ok := d.GetOk("store_passphrase"); ok {
 		req = req.StorePassphrase(val.(string))
 	}
-	if val, ok := d.GetOk("truststore"); ok {
-		file, err := os.Open(val.(string))
-		if err != nil {
-			return req, err
-		}
+	if file, ok, err := OpenFileInput(d, "truststore", getSgTruststoreFilePattern(d), inputs); err != nil {
+		return req, err
+	} else if ok {
 		req = req.TrustStore(file)
 	}
 	return req, nil
 }
 
-func loadSgTruststorePutBody(req secretgroup_truststore.DefaultApiPutSecretGroupTruststoreRequest, d *schema.ResourceData) (secretgroup_truststore.DefaultApiPutSecretGroupTruststoreRequest, error) {
+func loadSgTruststorePutBody(req secretgroup_truststore.DefaultApiPutSecretGroupTruststoreRequest, d *schema.ResourceData, inputs *FileInputs) (secretgroup_truststore.DefaultApiPutSecretGroupTruststoreRequest, error) {
 	t := d.Get("type").(string)
 	if isSgTruststorePEM(t) {
-		return loadSgTruststorePEMPutBody(req, d)
+		return loadSgTruststorePEMPutBody(req, d, inputs)
 	} else {
-		return loadSgTruststoreOthersPutBody(req, d)
+		return loadSgTruststoreOthersPutBody(req, d, inputs)
 	}
 }
 
-func loadSgTruststorePEMPutBody(req secretgroup_truststore.DefaultApiPutSecretGroupTruststoreRequest, d *schema.ResourceData) (secretgroup_truststore.DefaultApiPutSecretGroupTruststoreRequest, error) {
+func loadSgTruststorePEMPutBody(req secretgroup_truststore.DefaultApiPutSecretGroupTruststoreRequest, d *schema.ResourceData, inputs *FileInputs) (secretgroup_truststore.DefaultApiPutSecretGroupTruststoreRequest, error) {
 	// if val, ok := d.GetOk("expiration_date"); ok {
 	// 	req = req.ExpirationDate(val.(string))
 	// }
-	if val, ok := d.GetOk("truststore"); ok {
-		file, err := os.Open(val.(string))
-		if err != nil {
-			return req, err
-		}
+	if file, ok, err := OpenFileInput(d, "truststore", getSgTruststoreFilePattern(d), inputs); err != nil {
+		return req, err
+	} else if ok {
 		req = req.TrustStore(file)
 	}
 	if val, ok := d.GetOk("name"); ok {
@@ -378,33 +417,39 @@ func loadSgTruststorePEMPutBody(req secretgroup_truststore.DefaultApiPutSecretGr
 	return req, nil
 }
 
-func loadSgTruststoreOthersPutBody(req secretgroup_truststore.DefaultApiPutSecretGroupTruststoreRequest, d *schema.ResourceData) (secretgroup_truststore.DefaultApiPutSecretGroupTruststoreRequest, error) {
+func loadSgTruststoreOthersPutBody(req secretgroup_truststore.DefaultApiPutSecretGroupTruststoreRequest, d *schema.ResourceData, inputs *FileInputs) (secretgroup_truststore.DefaultApiPutSecretGroupTruststoreRequest, error) {
 	if val, ok := d.GetOk("algorithm"); ok {
 		req = req.Algorithm(val.(string))
 	}
 	if val, ok := d.GetOk("store_passphrase"); ok {
 		req = req.StorePassphrase(val.(string))
 	}
-	return loadSgTruststorePEMPutBody(req, d)
+	return loadSgTruststorePEMPutBody(req, d, inputs)
 }
 
 // depending on the type of the truststore, checks if required properties are checked
 func validateTruststoreInput(d *schema.ResourceDiff) error {
 	t := d.Get("type").(string)
 	var required []string
-	if isSgTruststorePEM(t) {
-		required = []string{"truststore"}
-	} else {
-		required = []string{"truststore", "store_passphrase"}
+	if !isSgTruststorePEM(t) {
+		required = []string{"store_passphrase"}
+	}
+	if !IsFileInputSet(d, "truststore") {
+		return fmt.Errorf("missing required attribute \"truststore\" path (or \"truststore_content\", \"truststore_base64\") for truststore type %s", t)
 	}
 	for _, r := range required {
 		if _, ok := d.GetOk(r); !ok {
-			return fmt.Errorf("missing required attribute \"%s\" path for truststore type %s", r, t)
+			return fmt.Errorf("missing required attribute \"%s\" for truststore type %s", r, t)
 		}
 	}
 	return nil
 }
 
+// returns the temporary file name pattern used to upload inline content depending on the truststore type
+func getSgTruststoreFilePattern(d resourceDataGetter) string {
+	return "truststore-*" + getSgFileExtension(d.Get("type").(string))
+}
+
 // returns the composed of the secret
 func decomposeSgTruststoreId(d *schema.ResourceData) (string, string, string, string) {
 	s := DecomposeResourceId(d.Id())
@@ -413,14 +458,14 @@ func decomposeSgTruststoreId(d *schema.ResourceData) (string, string, string, st
 
 func getSgTruststorePEMUpdatableAttributes() []string {
 	attributes := [...]string{
-		"allow_expired_cert", "name", "type", "truststore",
+		"allow_expired_cert", "name", "type", "truststore", "truststore_digest",
 	}
 	return attributes[:]
 }
 
 func getSgTruststoreOthersUpdatableAttributes() []string {
 	attributes := [...]string{
-		"allow_expired_cert", "name", "type", "truststore", "store_passphrase", "algorithm",
+		"allow_expired_cert", "name", "type", "truststore", "truststore_digest", "store_passphrase", "algorithm",
 	}
 	return attributes[:]
 }
@@ -429,6 +474,6 @@ func getSgTruststoreOthersUpdatableAttributes() []string {
  * Returns authentication context (includes authorization header)
  */
 func getSgTruststoreAuthCtx(ctx context.Context, pco *ProviderConfOutput) context.Context {
-	tmp := context.WithValue(ctx, secretgroup_truststore.ContextAccessToken, pco.access_token)
+	tmp := context.WithValue(ctx, secretgroup_truststore.ContextAccessToken, pco.tokensource.Token(ctx))
 	return context.WithValue(tmp, secretgroup_truststore.ContextServerIndex, pco.server_index)
 }
@@ -17,6 +17,7 @@ func resourceTeam() *schema.Resource {
 		ReadContext:   resourceTeamRead,
 		UpdateContext: resourceTeamUpdate,
 		DeleteContext: resourceTeamDelete,
+		CustomizeDiff: customizeDiffProviderDefaults,
 		Description: `
 		Creates a ` + "`" + `team` + "`" + ` for your ` + "`" + `org` + "`" + `.
 		`,
@@ -34,9 +35,10 @@ func resourceTeam() *schema.Resource {
 			},
 			"org_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The master organization id where the team is defined.",
+				Description: "The master organization id where the team is defined. Defaults to the provider's default_org_id.",
 			},
 			"parent_team_id": {
 				Type:        schema.TypeString,
@@ -318,7 +320,7 @@ func getTeamPutWatchAttributes() []string {
  * Returns authentication context (includes authorization header)
  */
 func getTeamAuthCtx(ctx context.Context, pco *ProviderConfOutput) context.Context {
-	tmp := context.WithValue(ctx, team.ContextAccessToken, pco.access_token)
+	tmp := context.WithValue(ctx, team.ContextAccessToken, pco.tokensource.Token(ctx))
 	return context.WithValue(tmp, team.ContextServerIndex, pco.server_index)
 }
 
@@ -16,6 +16,7 @@ func resourceTeamGroupMappings() *schema.Resource {
 		CreateContext: resourceTeamGroupMappingsCreate,
 		ReadContext:   resourceTeamGroupMappingsRead,
 		DeleteContext: resourceTeamGroupMappingsDelete,
+		CustomizeDiff: customizeDiffProviderDefaults,
 		UpdateContext: resourceTeamGroupMappingsUpdate,
 		Description: `
 		Maps identity providers' groups to a team.
@@ -42,9 +43,10 @@ func resourceTeamGroupMappings() *schema.Resource {
 			},
 			"org_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The master organization id where the team is defined.",
+				Description: "The master organization id where the team is defined. Defaults to the provider's default_org_id.",
 			},
 			"groupmappings": {
 				Type:        schema.TypeList,
@@ -300,7 +302,7 @@ func equalTeamGroupMapping(old, new interface{}) bool {
  * Returns authentication context (includes authorization header)
  */
 func getTeamGroupMappingsAuthCtx(ctx context.Context, pco *ProviderConfOutput) context.Context {
-	tmp := context.WithValue(ctx, team_group_mappings.ContextAccessToken, pco.access_token)
+	tmp := context.WithValue(ctx, team_group_mappings.ContextAccessToken, pco.tokensource.Token(ctx))
 	return context.WithValue(tmp, team_group_mappings.ContextServerIndex, pco.server_index)
 }
 
@@ -17,8 +17,10 @@ func resourceTeamMember() *schema.Resource {
 		CreateContext: resourceTeamMemberCreate,
 		ReadContext:   resourceTeamMemberRead,
 		DeleteContext: resourceTeamMemberDelete,
+		CustomizeDiff: customizeDiffProviderDefaults,
 		Description: `
 		Assignes a ` + "`" + `user` + "`" + ` to a ` + "`" + `team` + "`" + ` for your ` + "`" + `org` + "`" + `.
+		To manage the full list of members of a team, use anypoint_team_members_exclusive instead.
 		`,
 		Schema: map[string]*schema.Schema{
 			"last_updated": {
@@ -40,9 +42,10 @@ func resourceTeamMember() *schema.Resource {
 			},
 			"org_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The master organization id where the team is defined.",
+				Description: "The master organization id where the team is defined. Defaults to the provider's default_org_id.",
 			},
 			"user_id": {
 				Type:        schema.TypeString,
@@ -260,7 +263,7 @@ func search4MemberByIdInSlice(members []team_members.TeamMember, user_id string)
  * Returns authentication context (includes authorization header)
  */
 func getTeamMembersAuthCtx(ctx context.Context, pco *ProviderConfOutput) context.Context {
-	tmp := context.WithValue(ctx, team_members.ContextAccessToken, pco.access_token)
+	tmp := context.WithValue(ctx, team_members.ContextAccessToken, pco.tokensource.Token(ctx))
 	return context.WithValue(tmp, team_members.ContextServerIndex, pco.server_index)
 }
 
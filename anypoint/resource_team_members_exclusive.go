@@ -0,0 +1,392 @@
+package anypoint
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	team_members "github.com/mulesoft-anypoint/anypoint-client-go/team_members"
+)
+
+const TEAM_MEMBERS_PAGE_SIZE = 200
+
+type orgUserSummary struct {
+	Id       string `json:"id"`
+	Username string `json:"username"`
+}
+
+type orgUsersSearchResponse struct {
+	Data  []orgUserSummary `json:"data"`
+	Total int              `json:"total,omitempty"`
+}
+
+func resourceTeamMembersExclusive() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceTeamMembersExclusiveCreate,
+		ReadContext:   resourceTeamMembersExclusiveRead,
+		UpdateContext: resourceTeamMembersExclusiveUpdate,
+		DeleteContext: resourceTeamMembersExclusiveDelete,
+		CustomizeDiff: customizeDiffProviderDefaults,
+		Description: `
+		Manages the full list of members of a ` + "`" + `team` + "`" + `. Members are given by user id or by username.
+		Members that are not part of the configuration are removed from the team, they are reported as drift when added outside of terraform.
+		Members assigned via external identity provider group mappings are neither removed nor reported unless configured or ignore_external_group_members is false.
+		Do not use this resource together with anypoint_team_member on the same team.
+		`,
+		Schema: map[string]*schema.Schema{
+			"last_updated": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The last time this resource has been updated locally.",
+			},
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The unique id of this resource composed by {org_id}/{team_id}",
+			},
+			"org_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The master organization id where the team is defined. Defaults to the provider's default_org_id.",
+			},
+			"team_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The id of the team.",
+			},
+			"user_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The ids of the users that are members of the team.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"usernames": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The usernames of the users that are members of the team.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"membership_type": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "member",
+				Description:      "The membership type of all members. Only users may be team maintainers. Enum values: member, maintainer",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"member", "maintainer"}, true)),
+			},
+			"ignore_external_group_members": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the members assigned via external identity provider group mappings are ignored.",
+			},
+			"username_ids": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The user ids of the members given by username.",
+			},
+			"member_ids": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "The ids of all the managed members of the team.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceTeamMembersExclusiveCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	orgid := d.Get("org_id").(string)
+	teamid := d.Get("team_id").(string)
+	if diags := syncTeamMembersExclusive(ctx, d, m); diags.HasError() {
+		return diags
+	}
+	d.SetId(ComposeResourceId([]string{orgid, teamid}))
+	d.Set("last_updated", time.Now().Format(time.RFC850))
+
+	return resourceTeamMembersExclusiveRead(ctx, d, m)
+}
+
+func resourceTeamMembersExclusiveRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid, teamid := decomposeTeamMembersExclusiveId(d)
+	members, err := listTeamMembersExclusive(ctx, &pco, orgid, teamid)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to get team " + teamid + " members",
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	// external group members are only reported when configured, so they are neither drift nor missing
+	configured := make(map[string]bool)
+	for _, id := range d.Get("user_ids").(*schema.Set).List() {
+		configured[id.(string)] = true
+	}
+	for _, id := range d.Get("username_ids").(map[string]interface{}) {
+		configured[id.(string)] = true
+	}
+	for id, member := range members {
+		if isTeamMemberExclusiveIgnored(d, member) && !configured[id] {
+			delete(members, id)
+		}
+	}
+	// members given by username are reported as usernames, the others as user ids
+	memberids := make([]interface{}, 0, len(members))
+	for id := range members {
+		memberids = append(memberids, id)
+	}
+	usernameids := make(map[string]interface{})
+	usernames := make([]interface{}, 0)
+	byusername := make(map[string]bool)
+	for username, id := range d.Get("username_ids").(map[string]interface{}) {
+		if _, ok := members[id.(string)]; ok {
+			usernameids[username] = id
+			usernames = append(usernames, username)
+			byusername[id.(string)] = true
+		}
+	}
+	userids := make([]interface{}, 0, len(members))
+	for id := range members {
+		if !byusername[id] {
+			userids = append(userids, id)
+		}
+	}
+	d.Set("member_ids", memberids)
+	d.Set("username_ids", usernameids)
+	d.Set("usernames", usernames)
+	d.Set("user_ids", userids)
+	d.Set("org_id", orgid)
+	d.Set("team_id", teamid)
+	d.SetId(ComposeResourceId([]string{orgid, teamid}))
+
+	return diags
+}
+
+func resourceTeamMembersExclusiveUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if d.HasChanges("user_ids", "usernames", "membership_type", "ignore_external_group_members") {
+		if diags := syncTeamMembersExclusive(ctx, d, m); diags.HasError() {
+			return diags
+		}
+		d.Set("last_updated", time.Now().Format(time.RFC850))
+	}
+	return resourceTeamMembersExclusiveRead(ctx, d, m)
+}
+
+func resourceTeamMembersExclusiveDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid := d.Get("org_id").(string)
+	teamid := d.Get("team_id").(string)
+	members, err := listTeamMembersExclusive(ctx, &pco, orgid, teamid)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to get team " + teamid + " members",
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	for userid, member := range members {
+		if isTeamMemberExclusiveIgnored(d, member) {
+			continue
+		}
+		if err := removeTeamMemberExclusive(ctx, &pco, orgid, teamid, userid); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to delete team " + teamid + " member " + userid,
+				Detail:   err.Error(),
+			})
+			return diags
+		}
+	}
+	// d.SetId("") is automatically called assuming delete returns no errors, but
+	// it is added here for explicitness.
+	d.SetId("")
+
+	return diags
+}
+
+// adds and removes members so the team's members match the configured ones
+func syncTeamMembersExclusive(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid := d.Get("org_id").(string)
+	teamid := d.Get("team_id").(string)
+	membershiptype := d.Get("membership_type").(string)
+	//resolve desired members
+	usernameids, err := resolveTeamMembersUsernames(ctx, &pco, orgid, d.Get("usernames").(*schema.Set).List())
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to resolve usernames of team " + teamid + " members",
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	desired := make(map[string]bool)
+	for _, id := range d.Get("user_ids").(*schema.Set).List() {
+		desired[id.(string)] = true
+	}
+	for _, id := range usernameids {
+		desired[id.(string)] = true
+	}
+	//current members
+	members, err := listTeamMembersExclusive(ctx, &pco, orgid, teamid)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to get team " + teamid + " members",
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	//converge
+	for userid := range desired {
+		if member, ok := members[userid]; ok && member.GetMembershipType() == membershiptype {
+			continue
+		}
+		if err := addTeamMemberExclusive(ctx, &pco, orgid, teamid, userid, membershiptype); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to add team " + teamid + " member " + userid,
+				Detail:   err.Error(),
+			})
+			return diags
+		}
+	}
+	for userid, member := range members {
+		if desired[userid] || isTeamMemberExclusiveIgnored(d, member) {
+			continue
+		}
+		if err := removeTeamMemberExclusive(ctx, &pco, orgid, teamid, userid); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to delete team " + teamid + " member " + userid,
+				Detail:   err.Error(),
+			})
+			return diags
+		}
+	}
+	d.Set("username_ids", usernameids)
+	return diags
+}
+
+// lists all the members of the team indexed by user id, paginating through the results
+func listTeamMembersExclusive(ctx context.Context, pco *ProviderConfOutput, orgid, teamid string) (map[string]team_members.TeamMember, error) {
+	authctx := getTeamMembersAuthCtx(ctx, pco)
+	members := make(map[string]team_members.TeamMember)
+	for offset := 0; ; offset += TEAM_MEMBERS_PAGE_SIZE {
+		res, httpr, err := pco.teammembersclient.DefaultApi.OrganizationsOrgIdTeamsTeamIdMembersGet(authctx, orgid, teamid).Offset(int32(offset)).Limit(TEAM_MEMBERS_PAGE_SIZE).Execute()
+		if err != nil {
+			return nil, fmt.Errorf("%s", getHttpErrorDetails(httpr, err))
+		}
+		httpr.Body.Close()
+		data := res.GetData()
+		for _, member := range data {
+			members[member.GetId()] = member
+		}
+		if len(data) < TEAM_MEMBERS_PAGE_SIZE {
+			break
+		}
+	}
+	return members, nil
+}
+
+// whether the member is assigned via external identity provider group mappings and left out of the exclusive management
+func isTeamMemberExclusiveIgnored(d *schema.ResourceData, member team_members.TeamMember) bool {
+	return d.Get("ignore_external_group_members").(bool) && member.GetIsAssignedViaExternalGroups()
+}
+
+func addTeamMemberExclusive(ctx context.Context, pco *ProviderConfOutput, orgid, teamid, userid, membershiptype string) error {
+	authctx := getTeamMembersAuthCtx(ctx, pco)
+	body := team_members.NewTeamMemberPutBodyWithDefaults()
+	body.SetMembershipType(membershiptype)
+	httpr, err := pco.teammembersclient.DefaultApi.OrganizationsOrgIdTeamsTeamIdMembersUserIdPut(authctx, orgid, teamid, userid).TeamMemberPutBody(*body).Execute()
+	if err != nil {
+		var details string
+		if httpr != nil && httpr.StatusCode >= 400 {
+			defer httpr.Body.Close()
+			b, _ := io.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		return fmt.Errorf("%s", details)
+	}
+	defer httpr.Body.Close()
+	return nil
+}
+
+func removeTeamMemberExclusive(ctx context.Context, pco *ProviderConfOutput, orgid, teamid, userid string) error {
+	authctx := getTeamMembersAuthCtx(ctx, pco)
+	httpr, err := pco.teammembersclient.DefaultApi.OrganizationsOrgIdTeamsTeamIdMembersUserIdDelete(authctx, orgid, teamid, userid).Execute()
+	if err != nil {
+		if httpr != nil && httpr.StatusCode == http.StatusNotFound {
+			return nil
+		}
+		var details string
+		if httpr != nil && httpr.StatusCode >= 400 {
+			defer httpr.Body.Close()
+			b, _ := io.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		return fmt.Errorf("%s", details)
+	}
+	defer httpr.Body.Close()
+	return nil
+}
+
+// resolves the user id of each of the given usernames
+func resolveTeamMembersUsernames(ctx context.Context, pco *ProviderConfOutput, orgid string, usernames []interface{}) (map[string]interface{}, error) {
+	authctx := getAccountsAuthCtx(ctx, pco)
+	result := make(map[string]interface{}, len(usernames))
+	for _, item := range usernames {
+		username := item.(string)
+		path := fmt.Sprintf("/organizations/%s/users?search=%s", url.PathEscape(orgid), url.QueryEscape(username))
+		var res orgUsersSearchResponse
+		httpr, err := pco.accountsclient.Execute(authctx, http.MethodGet, path, nil, &res)
+		if err != nil {
+			return nil, fmt.Errorf("unable to search user %s. %s", username, getHttpErrorDetails(httpr, err))
+		}
+		httpr.Body.Close()
+		for _, usr := range res.Data {
+			if usr.Username == username {
+				result[username] = usr.Id
+				break
+			}
+		}
+		if _, ok := result[username]; !ok {
+			return nil, fmt.Errorf("user %s not found in org %s", username, orgid)
+		}
+	}
+	return result, nil
+}
+
+func decomposeTeamMembersExclusiveId(d *schema.ResourceData) (string, string) {
+	s := DecomposeResourceId(d.Id())
+	return s[0], s[1]
+}
@@ -17,12 +17,24 @@ func resourceTeamRoles() *schema.Resource {
 		CreateContext: resourceTeamRolesCreate,
 		ReadContext:   resourceTeamRolesRead,
 		DeleteContext: resourceTeamRolesDelete,
+		CustomizeDiff: func(ctx context.Context, rd *schema.ResourceDiff, i interface{}) error {
+			if err := customizeDiffProviderDefaults(ctx, rd, i); err != nil {
+				return err
+			}
+			if !rd.HasChange("roles") || !rd.NewValueKnown("org_id") {
+				return nil
+			}
+			pco := i.(ProviderConfOutput)
+			return validateRolesContextParams(ctx, rd, &pco, rd.Get("org_id").(string), true)
+		},
 		Description: `
 		Attributes ` + "`" + `roles` + "`" + ` to your selected ` + "`" + `team` + "`" + ` for your ` + "`" + `org` + "`" + `.
 
 Depending on the ` + "`" + `role` + "`" + `, some roles are environment scoped others are business group scoped :
 * For environment scoped roles, the org id and environment id needs to be specified.
 * For business group scoped roles, only the org id is needed.
+
+The roles and their context params are validated at plan time, see the data source ` + "`" + `anypoint_permission_catalog` + "`" + `.
 		`,
 		Schema: map[string]*schema.Schema{
 			"last_updated": {
@@ -43,9 +55,10 @@ Depending on the ` + "`" + `role` + "`" + `, some roles are environment scoped o
 			},
 			"org_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The master organization id where the team is defined.",
+				Description: "The master organization id where the team is defined. Defaults to the provider's default_org_id.",
 			},
 			"roles": {
 				Type:     schema.TypeList,
@@ -367,7 +380,7 @@ func sortMapListRoles(roles []interface{}) {
  * Returns authentication context (includes authorization header)
  */
 func getTeamRolesAuthCtx(ctx context.Context, pco *ProviderConfOutput) context.Context {
-	tmp := context.WithValue(ctx, team_roles.ContextAccessToken, pco.access_token)
+	tmp := context.WithValue(ctx, team_roles.ContextAccessToken, pco.tokensource.Token(ctx))
 	return context.WithValue(tmp, team_roles.ContextServerIndex, pco.server_index)
 }
 
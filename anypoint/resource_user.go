@@ -16,6 +16,7 @@ func resourceUser() *schema.Resource {
 		ReadContext:   resourceUserRead,
 		UpdateContext: resourceUserUpdate,
 		DeleteContext: resourceUserDelete,
+		CustomizeDiff: customizeDiffProviderDefaults,
 		Description: `
 		Creates a ` + "`" + `user` + "`" + ` for your org. 
 
@@ -35,9 +36,10 @@ func resourceUser() *schema.Resource {
 			},
 			"org_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The master organization id where the user is defined.",
+				Description: "The master organization id where the user is defined. Defaults to the provider's default_org_id.",
 			},
 			"username": {
 				Type:        schema.TypeString,
@@ -372,7 +374,7 @@ func getUserWatchAttributes() []string {
  * Returns authentication context (includes authorization header)
  */
 func getUserAuthCtx(ctx context.Context, pco *ProviderConfOutput) context.Context {
-	tmp := context.WithValue(ctx, user.ContextAccessToken, pco.access_token)
+	tmp := context.WithValue(ctx, user.ContextAccessToken, pco.tokensource.Token(ctx))
 	return context.WithValue(tmp, user.ContextServerIndex, pco.server_index)
 }
 
@@ -0,0 +1,475 @@
+package anypoint
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	USER_INVITE_STATUS_PENDING  = "pending"
+	USER_INVITE_STATUS_ACCEPTED = "accepted"
+	USER_INVITE_STATUS_EXPIRED  = "expired"
+)
+
+type userInviteTeam struct {
+	TeamId         string `json:"team_id"`
+	MembershipType string `json:"membership_type,omitempty"`
+}
+
+type userInvite struct {
+	Id        string           `json:"id,omitempty"`
+	Email     string           `json:"email,omitempty"`
+	OrgId     string           `json:"organization_id,omitempty"`
+	InviterId string           `json:"inviter_id,omitempty"`
+	Teams     []userInviteTeam `json:"teams,omitempty"`
+	Status    string           `json:"status,omitempty"`
+	CreatedAt string           `json:"created_at,omitempty"`
+	ExpiresAt string           `json:"expires_at,omitempty"`
+}
+
+type userInvitesPostBody struct {
+	Emails []string         `json:"emails"`
+	Teams  []userInviteTeam `json:"teams,omitempty"`
+}
+
+type userInvitesResponse struct {
+	Data  []userInvite `json:"data"`
+	Total int          `json:"total,omitempty"`
+}
+
+type userInviteUser struct {
+	Id       string `json:"id"`
+	Username string `json:"username,omitempty"`
+	Email    string `json:"email,omitempty"`
+}
+
+type userInviteUsersResponse struct {
+	Data  []userInviteUser `json:"data"`
+	Total int              `json:"total,omitempty"`
+}
+
+func resourceUserInvitation() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceUserInvitationCreate,
+		ReadContext:   resourceUserInvitationRead,
+		UpdateContext: resourceUserInvitationUpdate,
+		DeleteContext: resourceUserInvitationDelete,
+		CustomizeDiff: func(ctx context.Context, rd *schema.ResourceDiff, i interface{}) error {
+			if err := customizeDiffProviderDefaults(ctx, rd, i); err != nil {
+				return err
+			}
+			return customizeDiffUserInvitationResend(ctx, rd, i)
+		},
+		Description: `
+		Invites a ` + "`" + `user` + "`" + ` to join your org by email, the invited user is assigned to the given teams once the invitation is accepted.
+		Roles are granted through the teams' roles.
+		The status of the invitation is tracked (pending, accepted or expired), an expired invitation is resent on the next apply unless resend_when_expired is false.
+		Destroying a pending or expired invitation revokes it. Destroying an accepted invitation only removes it from the terraform state, the user remains in the org.
+		Only pending or expired invitations can be imported, accepted invitations are no longer returned by the platform.
+		`,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The unique id of this invitation composed by {org_id}/{invite_id}",
+			},
+			"org_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The organization id where the user is invited. Defaults to the provider's default_org_id.",
+			},
+			"email": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The email address the invitation is sent to.",
+			},
+			"team": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The teams the user is assigned to once the invitation is accepted.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"team_id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+							Description: "The id of the team.",
+						},
+						"membership_type": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							Default:          "member",
+							ForceNew:         true,
+							Description:      "Whether the user is a regular member or a maintainer of the team. Enum values: member, maintainer",
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"member", "maintainer"}, true)),
+						},
+					},
+				},
+			},
+			"resend_when_expired": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the invitation is resent on the next apply when it has expired.",
+			},
+			"invite_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The id of the invitation generated by the anypoint platform.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the invitation. Either pending, accepted or expired.",
+			},
+			"user_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The id of the user who accepted the invitation.",
+			},
+			"inviter_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The id of the user who sent the invitation.",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time the invitation was sent.",
+			},
+			"expires_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time the invitation expires.",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func resourceUserInvitationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid := d.Get("org_id").(string)
+	email := d.Get("email").(string)
+	authctx := getAccountsAuthCtx(ctx, &pco)
+	body := newUserInvitesPostBody(d)
+	//perform request
+	var res []userInvite
+	httpr, err := pco.accountsclient.Execute(authctx, http.MethodPost, getUserInvitesPath(orgid), body, &res)
+	if err != nil {
+		var details string
+		if httpr != nil && httpr.StatusCode >= 400 {
+			defer httpr.Body.Close()
+			b, _ := io.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		diags := append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to invite user " + email + " to org " + orgid,
+			Detail:   details,
+		})
+		return diags
+	}
+	defer httpr.Body.Close()
+	if len(res) == 0 {
+		diags := append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to invite user " + email + " to org " + orgid,
+			Detail:   "no invitation returned by the platform",
+		})
+		return diags
+	}
+	d.SetId(ComposeResourceId([]string{orgid, res[0].Id}))
+
+	return resourceUserInvitationRead(ctx, d, m)
+}
+
+func resourceUserInvitationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid, inviteid := decomposeUserInvitationId(d)
+	authctx := getAccountsAuthCtx(ctx, &pco)
+	//perform request
+	var res userInvite
+	httpr, err := pco.accountsclient.Execute(authctx, http.MethodGet, getUserInvitePath(orgid, inviteid), nil, &res)
+	if err != nil {
+		if httpr != nil && httpr.StatusCode == http.StatusNotFound {
+			// the invitation is consumed once accepted
+			return readAcceptedUserInvitation(ctx, d, &pco, orgid, inviteid)
+		}
+		var details string
+		if httpr != nil && httpr.StatusCode >= 400 {
+			defer httpr.Body.Close()
+			b, _ := io.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		diags := append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to read invitation " + inviteid + " in org " + orgid,
+			Detail:   details,
+		})
+		return diags
+	}
+	defer httpr.Body.Close()
+	//process data
+	data := flattenUserInviteData(&res)
+	if err := setUserInviteAttributesToResourceData(d, data); err != nil {
+		diags := append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to set invitation " + inviteid + " in org " + orgid,
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	if err := d.Set("team", data["teams"]); err != nil {
+		diags := append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to set invitation " + inviteid + " teams in org " + orgid,
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	d.SetId(ComposeResourceId([]string{orgid, inviteid}))
+	d.Set("org_id", orgid)
+	d.Set("invite_id", inviteid)
+
+	return diags
+}
+
+func resourceUserInvitationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid, inviteid := decomposeUserInvitationId(d)
+	// the status is unknown in the plan when a resend is due, the value from the state is used instead
+	status, _ := d.GetChange("status")
+	if status.(string) == USER_INVITE_STATUS_EXPIRED && d.Get("resend_when_expired").(bool) {
+		authctx := getAccountsAuthCtx(ctx, &pco)
+		httpr, err := pco.accountsclient.Execute(authctx, http.MethodPost, getUserInvitePath(orgid, inviteid)+"/resend", nil, nil)
+		if err != nil {
+			var details string
+			if httpr != nil && httpr.StatusCode >= 400 {
+				defer httpr.Body.Close()
+				b, _ := io.ReadAll(httpr.Body)
+				details = string(b)
+			} else {
+				details = err.Error()
+			}
+			diags := append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to resend invitation " + inviteid + " in org " + orgid,
+				Detail:   details,
+			})
+			return diags
+		}
+		defer httpr.Body.Close()
+	}
+
+	return resourceUserInvitationRead(ctx, d, m)
+}
+
+func resourceUserInvitationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	pco := m.(ProviderConfOutput)
+	orgid, inviteid := decomposeUserInvitationId(d)
+	// NOTE: an accepted invitation cannot be revoked
+	// Therefore we are only removing reference here
+	if d.Get("status").(string) == USER_INVITE_STATUS_ACCEPTED {
+		d.SetId("")
+		return diags
+	}
+	authctx := getAccountsAuthCtx(ctx, &pco)
+	httpr, err := pco.accountsclient.Execute(authctx, http.MethodDelete, getUserInvitePath(orgid, inviteid), nil, nil)
+	if err != nil {
+		if httpr != nil && httpr.StatusCode == http.StatusNotFound {
+			d.SetId("")
+			return diags
+		}
+		var details string
+		if httpr != nil && httpr.StatusCode >= 400 {
+			defer httpr.Body.Close()
+			b, _ := io.ReadAll(httpr.Body)
+			details = string(b)
+		} else {
+			details = err.Error()
+		}
+		diags := append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to revoke invitation " + inviteid + " in org " + orgid,
+			Detail:   details,
+		})
+		return diags
+	}
+	defer httpr.Body.Close()
+	// d.SetId("") is automatically called assuming delete returns no errors, but
+	// it is added here for explicitness.
+	d.SetId("")
+
+	return diags
+}
+
+// once accepted, the invitation is no longer returned by the platform, the invited user is looked up by email instead.
+// if the user is not found, the invitation has been revoked outside of terraform and is removed from the state.
+// the email isn't known when importing, accepted invitations can't be imported
+func readAcceptedUserInvitation(ctx context.Context, d *schema.ResourceData, pco *ProviderConfOutput, orgid, inviteid string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	email := d.Get("email").(string)
+	if email == "" {
+		diags := append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to read invitation " + inviteid + " in org " + orgid,
+			Detail:   "the invitation is not found, it has either been accepted or revoked. Only pending or expired invitations can be imported.",
+		})
+		return diags
+	}
+	usr, err := searchUserInviteUser(ctx, pco, orgid, email)
+	if err != nil {
+		diags := append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to read invitation " + inviteid + " in org " + orgid,
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	if usr == nil {
+		d.SetId("")
+		return diags
+	}
+	d.Set("status", USER_INVITE_STATUS_ACCEPTED)
+	d.Set("user_id", usr.Id)
+	return diags
+}
+
+// searches a user of the org by email, returns nil if not found
+func searchUserInviteUser(ctx context.Context, pco *ProviderConfOutput, orgid, email string) (*userInviteUser, error) {
+	authctx := getAccountsAuthCtx(ctx, pco)
+	path := fmt.Sprintf("/organizations/%s/users?search=%s", url.PathEscape(orgid), url.QueryEscape(email))
+	var res userInviteUsersResponse
+	httpr, err := pco.accountsclient.Execute(authctx, http.MethodGet, path, nil, &res)
+	if err != nil {
+		return nil, fmt.Errorf("unable to search user %s in org %s. %s", email, orgid, getHttpErrorDetails(httpr, err))
+	}
+	httpr.Body.Close()
+	for _, usr := range res.Data {
+		if strings.EqualFold(usr.Email, email) {
+			return &usr, nil
+		}
+	}
+	return nil, nil
+}
+
+// plans the resend of the invitation when it has expired
+func customizeDiffUserInvitationResend(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	if d.Get("status").(string) == USER_INVITE_STATUS_EXPIRED && d.Get("resend_when_expired").(bool) {
+		if err := d.SetNewComputed("status"); err != nil {
+			return err
+		}
+		return d.SetNewComputed("expires_at")
+	}
+	return nil
+}
+
+func newUserInvitesPostBody(d *schema.ResourceData) *userInvitesPostBody {
+	body := &userInvitesPostBody{
+		Emails: []string{d.Get("email").(string)},
+	}
+	teams := d.Get("team").([]interface{})
+	body.Teams = make([]userInviteTeam, 0, len(teams))
+	for _, item := range teams {
+		if item == nil {
+			continue
+		}
+		team := item.(map[string]interface{})
+		body.Teams = append(body.Teams, userInviteTeam{
+			TeamId:         team["team_id"].(string),
+			MembershipType: team["membership_type"].(string),
+		})
+	}
+	return body
+}
+
+func flattenUserInviteData(invite *userInvite) map[string]interface{} {
+	item := make(map[string]interface{})
+	item["invite_id"] = invite.Id
+	item["email"] = invite.Email
+	item["inviter_id"] = invite.InviterId
+	item["status"] = getUserInviteStatus(invite)
+	item["created_at"] = invite.CreatedAt
+	item["expires_at"] = invite.ExpiresAt
+	teams := make([]interface{}, len(invite.Teams))
+	for i, team := range invite.Teams {
+		teams[i] = map[string]interface{}{
+			"team_id":         team.TeamId,
+			"membership_type": team.MembershipType,
+		}
+	}
+	item["teams"] = teams
+	return item
+}
+
+// returns the status of the invitation, computed from its expiration time if not provided by the platform
+func getUserInviteStatus(invite *userInvite) string {
+	if invite.Status != "" {
+		return strings.ToLower(invite.Status)
+	}
+	if t, err := time.Parse(time.RFC3339, invite.ExpiresAt); err == nil && time.Now().After(t) {
+		return USER_INVITE_STATUS_EXPIRED
+	}
+	return USER_INVITE_STATUS_PENDING
+}
+
+func setUserInviteAttributesToResourceData(d *schema.ResourceData, data map[string]interface{}) error {
+	attributes := getUserInviteAttributes()
+	if data != nil {
+		for _, attr := range attributes {
+			if val, ok := data[attr]; ok {
+				if err := d.Set(attr, val); err != nil {
+					return fmt.Errorf("unable to set invitation attribute %s\n\tdetails: %s", attr, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func getUserInviteAttributes() []string {
+	attributes := [...]string{
+		"invite_id", "email", "inviter_id", "status", "created_at", "expires_at",
+	}
+	return attributes[:]
+}
+
+func decomposeUserInvitationId(d *schema.ResourceData) (string, string) {
+	s := DecomposeResourceId(d.Id())
+	return s[0], s[1]
+}
+
+func getUserInvitesPath(orgid string) string {
+	return fmt.Sprintf("/organizations/%s/invites", url.PathEscape(orgid))
+}
+
+func getUserInvitePath(orgid, inviteid string) string {
+	return getUserInvitesPath(orgid) + "/" + url.PathEscape(inviteid)
+}
@@ -14,6 +14,7 @@ func resourceUserRolegroup() *schema.Resource {
 		CreateContext: resourceUserRolegroupCreate,
 		ReadContext:   resourceUserRolegroupRead,
 		DeleteContext: resourceUserRolegroupDelete,
+		CustomizeDiff: customizeDiffProviderDefaults,
 		DeprecationMessage: `
 		This resource is deprecated, please use ` + "`" + `teams` + "`" + `, ` + "`" + `team_members` + "`" + `team_roles` + "`" + ` instead.
 		`,
@@ -34,9 +35,10 @@ func resourceUserRolegroup() *schema.Resource {
 			},
 			"org_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The master organization id where the role-group is defined.",
+				Description: "The master organization id where the role-group is defined. Defaults to the provider's default_org_id.",
 			},
 			"user_id": {
 				Type:        schema.TypeString,
@@ -209,6 +211,6 @@ func resourceUserRolegroupDelete(ctx context.Context, d *schema.ResourceData, m
 Returns authentication context (includes authorization header)
 */
 func getUserRolegroupsAuthCtx(ctx context.Context, pco *ProviderConfOutput) context.Context {
-	tmp := context.WithValue(ctx, user_rolegroups.ContextAccessToken, pco.access_token)
+	tmp := context.WithValue(ctx, user_rolegroups.ContextAccessToken, pco.tokensource.Token(ctx))
 	return context.WithValue(tmp, user_rolegroups.ContextServerIndex, pco.server_index)
 }
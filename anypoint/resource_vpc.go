@@ -19,6 +19,7 @@ func resourceVPC() *schema.Resource {
 		ReadContext:   resourceVPCRead,
 		UpdateContext: resourceVPCUpdate,
 		DeleteContext: resourceVPCDelete,
+		CustomizeDiff: customizeDiffProviderDefaults,
 		Description: `
 		Creates and manages a ` + "`" + `vpc` + "`" + `component.
 		`,
@@ -36,9 +37,10 @@ func resourceVPC() *schema.Resource {
 			},
 			"org_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The organization id where the vpc is defined.",
+				Description: "The organization id where the vpc is defined. Defaults to the provider's default_org_id.",
 			},
 			"name": {
 				Type:        schema.TypeString,
@@ -158,6 +160,12 @@ func resourceVPC() *schema.Resource {
 					},
 				},
 			},
+			"deletion_protection": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the vpc is protected from deletion. While enabled, the vpc cannot be destroyed or replaced, it has to be set to false in an apply first.",
+			},
 		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -280,6 +288,9 @@ func resourceVPCUpdate(ctx context.Context, d *schema.ResourceData, m interface{
 
 func resourceVPCDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
+	if diags := checkDeletionProtection(d, "Unable to delete vpc "+d.Id()); diags != nil {
+		return diags
+	}
 	pco := m.(ProviderConfOutput)
 	vpcid := d.Id()
 	orgid := d.Get("org_id").(string)
@@ -366,7 +377,7 @@ func newVPCBody(d *schema.ResourceData) *vpc.VpcCore {
  * Returns authentication context (includes authorization header)
  */
 func getVPCAuthCtx(ctx context.Context, pco *ProviderConfOutput) context.Context {
-	tmp := context.WithValue(ctx, vpc.ContextAccessToken, pco.access_token)
+	tmp := context.WithValue(ctx, vpc.ContextAccessToken, pco.tokensource.Token(ctx))
 	return context.WithValue(tmp, vpc.ContextServerIndex, pco.server_index)
 }
 
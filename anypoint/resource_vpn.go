@@ -16,6 +16,7 @@ func resourceVPN() *schema.Resource {
 		CreateContext: resourceVPNCreate,
 		ReadContext:   resourceVPNRead,
 		DeleteContext: resourceVPNDelete,
+		CustomizeDiff: customizeDiffProviderDefaults,
 		// UpdateContext: resourceVPNUpdate,
 		Description: `
 		Creates and manages a ` + "`" + `vpn` + "`" + `component.
@@ -28,9 +29,10 @@ func resourceVPN() *schema.Resource {
 			},
 			"org_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "The organization id where the vpn is defined.",
+				Description: "The organization id where the vpn is defined. Defaults to the provider's default_org_id.",
 			},
 			"vpc_id": {
 				Type:        schema.TypeString,
@@ -339,7 +341,7 @@ func newVPNBody(d *schema.ResourceData) *vpn.VpnPostReqBody {
  * Returns authentication context (includes authorization header)
  */
 func getVPNAuthCtx(ctx context.Context, pco *ProviderConfOutput) context.Context {
-	tmp := context.WithValue(ctx, vpn.ContextAccessToken, pco.access_token)
+	tmp := context.WithValue(ctx, vpn.ContextAccessToken, pco.tokensource.Token(ctx))
 	return context.WithValue(tmp, vpn.ContextServerIndex, pco.server_index)
 }
 
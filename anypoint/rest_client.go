@@ -0,0 +1,162 @@
+package anypoint
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+/*
+ * Minimal JSON client for the anypoint platform endpoints that are not covered by the generated anypoint-client-go libraries.
+ * It follows the generated clients conventions: the access token and the control plane's server index are read from the context.
+ */
+
+type restContextKey string
+
+const (
+	// RestContextAccessToken takes a string oauth2 access token as authentication for the request.
+	RestContextAccessToken = restContextKey("accesstoken")
+	// RestContextServerIndex uses a server configuration from the index.
+	RestContextServerIndex = restContextKey("serverIndex")
+	// RestContextHeaders takes a map[string]string of additional headers sent with the request.
+	RestContextHeaders = restContextKey("headers")
+)
+
+// the anypoint control planes' base urls, in the same order as the generated clients' servers
+var REST_CLIENT_SERVERS = []string{
+	"https://anypoint.mulesoft.com",
+	"https://eu1.anypoint.mulesoft.com",
+	"https://gov.anypoint.mulesoft.com",
+}
+
+type RestAPIClient struct {
+	basePath   string
+	httpClient *http.Client
+}
+
+// creates a new client for the api hosted under the given base path (i.e. /apimanager/api/v1).
+// the default http client is used if httpClient is nil.
+func NewRestAPIClient(basePath string, httpClient *http.Client) *RestAPIClient {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &RestAPIClient{
+		basePath:   basePath,
+		httpClient: httpClient,
+	}
+}
+
+// Execute sends a request with the given json body (if not nil) to the given path relative to the client's base path.
+// The response body is decoded into result (if not nil).
+// In case of a response with a status code >= 300, returns an error along with the http response, its body can still be read.
+func (c *RestAPIClient) Execute(ctx context.Context, method string, path string, body interface{}, result interface{}) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewBuffer(b)
+	}
+	req, err := c.newRequest(ctx, method, path, reader)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return c.do(req, result)
+}
+
+// ExecuteRaw sends a request with the given body and content type to the given path relative to the client's base path.
+// Used for non json payloads like multipart forms. The response body is decoded into result (if not nil).
+func (c *RestAPIClient) ExecuteRaw(ctx context.Context, method string, path string, body io.Reader, contentType string, result interface{}) (*http.Response, error) {
+	req, err := c.newRequest(ctx, method, path, body)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	return c.do(req, result)
+}
+
+func (c *RestAPIClient) newRequest(ctx context.Context, method string, path string, body io.Reader) (*http.Request, error) {
+	server_index := 0
+	if val, ok := ctx.Value(RestContextServerIndex).(int); ok {
+		server_index = val
+	}
+	if server_index < 0 || server_index >= len(REST_CLIENT_SERVERS) {
+		return nil, fmt.Errorf("invalid server index %d", server_index)
+	}
+	url := REST_CLIENT_SERVERS[server_index] + c.basePath + "/" + strings.TrimPrefix(path, "/")
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if token, ok := ctx.Value(RestContextAccessToken).(string); ok {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	if headers, ok := ctx.Value(RestContextHeaders).(map[string]string); ok {
+		for key, val := range headers {
+			req.Header.Set(key, val)
+		}
+	}
+	return req, nil
+}
+
+func (c *RestAPIClient) do(req *http.Request, result interface{}) (*http.Response, error) {
+	httpr, err := c.httpClient.Do(req)
+	if err != nil {
+		return httpr, err
+	}
+	b, err := io.ReadAll(httpr.Body)
+	httpr.Body.Close()
+	// the body is buffered so it can be read again by the caller
+	httpr.Body = io.NopCloser(bytes.NewBuffer(b))
+	if err != nil {
+		return httpr, err
+	}
+	if httpr.StatusCode >= 300 {
+		return httpr, fmt.Errorf("%s %s: %s", req.Method, req.URL.Path, httpr.Status)
+	}
+	if result != nil && len(b) > 0 {
+		if err := json.Unmarshal(b, result); err != nil {
+			return httpr, err
+		}
+	}
+	return httpr, nil
+}
+
+// returns the body of the given error response, or the error message if there's no response
+func getHttpErrorDetails(httpr *http.Response, err error) string {
+	if httpr != nil && httpr.StatusCode >= 400 {
+		defer httpr.Body.Close()
+		b, _ := io.ReadAll(httpr.Body)
+		return string(b)
+	}
+	return err.Error()
+}
+
+/*
+ * Returns authentication context (includes authorization header)
+ * for the requests sent to the accounts api (accountsclient)
+ */
+func getAccountsAuthCtx(ctx context.Context, pco *ProviderConfOutput) context.Context {
+	tmp := context.WithValue(ctx, RestContextAccessToken, pco.tokensource.Token(ctx))
+	return context.WithValue(tmp, RestContextServerIndex, pco.server_index)
+}
+
+/*
+ * Returns authentication context (includes authorization header)
+ * for the requests sent to the secrets manager api (secretsmanagerclient)
+ */
+func getSecretsManagerAuthCtx(ctx context.Context, pco *ProviderConfOutput) context.Context {
+	tmp := context.WithValue(ctx, RestContextAccessToken, pco.tokensource.Token(ctx))
+	return context.WithValue(tmp, RestContextServerIndex, pco.server_index)
+}
@@ -0,0 +1,151 @@
+package anypoint
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+/*
+ * Access token management.
+ * Applies can last longer than the access token lifetime (i.e. runtime fabrics upgrades or many deployments).
+ * When the provider authenticates with credentials, the token is renewed before it expires
+ * and a request rejected with a 401 is retried once with a renewed token.
+ */
+
+const (
+	// anypoint access tokens are valid for 60 minutes, used when the platform doesn't return the lifetime
+	TOKEN_DEFAULT_LIFETIME = 60 * time.Minute
+	// the token is renewed when it expires within the margin
+	TOKEN_EXPIRY_MARGIN = 5 * time.Minute
+)
+
+// authenticates against the platform and returns a new access token and its lifetime, 0 if unknown
+type tokenRefreshFunc func(ctx context.Context) (string, time.Duration, error)
+
+type tokenSource struct {
+	mu      sync.Mutex
+	token   string
+	expiry  time.Time
+	refresh tokenRefreshFunc
+}
+
+// creates a token source for a token provided as is, it cannot be renewed
+func newStaticTokenSource(token string) *tokenSource {
+	return &tokenSource{
+		token: token,
+	}
+}
+
+// creates a token source for a token obtained with the given refresh function, renewed when it expires
+func newRefreshableTokenSource(token string, lifetime time.Duration, refresh tokenRefreshFunc) *tokenSource {
+	return &tokenSource{
+		token:   token,
+		expiry:  getTokenExpiry(lifetime),
+		refresh: refresh,
+	}
+}
+
+// returns the expiry of a token delivered now with the given lifetime, the default lifetime is used if unknown
+func getTokenExpiry(lifetime time.Duration) time.Time {
+	if lifetime <= 0 {
+		lifetime = TOKEN_DEFAULT_LIFETIME
+	}
+	return time.Now().Add(lifetime)
+}
+
+// Token returns the current access token, renewed first if it is about to expire.
+// If the renewal fails the current token is returned, the request will then fail with the platform's error.
+func (ts *tokenSource) Token(ctx context.Context) string {
+	if ts == nil {
+		return ""
+	}
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	if ts.refresh != nil && time.Now().Add(TOKEN_EXPIRY_MARGIN).After(ts.expiry) {
+		if err := ts.renewLocked(ctx); err != nil {
+			log.Printf("[WARN] unable to renew access token: %s", err)
+		}
+	}
+	return ts.token
+}
+
+// renews the token unless it was already renewed since the given stale token was delivered.
+// returns the renewed token.
+func (ts *tokenSource) renew(ctx context.Context, stale string) (string, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	if ts.token != stale {
+		return ts.token, nil
+	}
+	if err := ts.renewLocked(ctx); err != nil {
+		return "", err
+	}
+	return ts.token, nil
+}
+
+func (ts *tokenSource) renewLocked(ctx context.Context) error {
+	token, lifetime, err := ts.refresh(ctx)
+	if err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] access token renewed")
+	ts.token = token
+	ts.expiry = getTokenExpiry(lifetime)
+	return nil
+}
+
+// returns true if the token can be renewed
+func (ts *tokenSource) isRefreshable() bool {
+	return ts != nil && ts.refresh != nil
+}
+
+// http transport retrying once the requests rejected with a 401 using a renewed token
+type tokenRefreshTransport struct {
+	base   http.RoundTripper
+	source *tokenSource
+}
+
+// returns an http client using the given token source to retry unauthorized requests
+func newTokenRefreshHttpClient(source *tokenSource) *http.Client {
+	return &http.Client{
+		Transport: &tokenRefreshTransport{
+			base:   http.DefaultTransport,
+			source: source,
+		},
+	}
+}
+
+func (t *tokenRefreshTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	res, err := t.base.RoundTrip(req)
+	if err != nil || res.StatusCode != http.StatusUnauthorized || !t.source.isRefreshable() {
+		return res, err
+	}
+	stale := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+	// the request can only be retried if it is authenticated and its body can be sent again
+	if stale == "" || (req.Body != nil && req.Body != http.NoBody && req.GetBody == nil) {
+		return res, err
+	}
+	token, rerr := t.source.renew(req.Context(), stale)
+	if rerr != nil {
+		log.Printf("[WARN] unable to renew access token: %s", rerr)
+		return res, err
+	}
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, berr := req.GetBody()
+		if berr != nil {
+			return res, err
+		}
+		retry.Body = body
+	}
+	retry.Header.Set("Authorization", "Bearer "+token)
+	io.Copy(io.Discard, res.Body)
+	res.Body.Close()
+	log.Printf("[DEBUG] retrying %s %s with a renewed access token", req.Method, req.URL.Path)
+	return t.base.RoundTrip(retry)
+}
@@ -2,14 +2,23 @@ package anypoint
 
 import (
 	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
 	"encoding/hex"
+	"encoding/pem"
+	"errors"
 	"fmt"
+	"log"
 	"math"
+	"os"
 	"reflect"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -114,6 +123,43 @@ func CalcSha1Digest(source string) string {
 	return hex.EncodeToString(hasher.Sum(nil))
 }
 
+// Decodes a x509 certificate given either in PEM format or as base64 encoded DER (i.e. SAML metadata)
+func DecodeX509Certificate(source string) (*x509.Certificate, error) {
+	if block, _ := pem.Decode([]byte(strings.TrimSpace(source))); block != nil {
+		return x509.ParseCertificate(block.Bytes)
+	}
+	der, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(source), ""))
+	if err != nil {
+		return nil, fmt.Errorf("certificate is neither in PEM format nor base64 encoded. %s", err.Error())
+	}
+	return x509.ParseCertificate(der)
+}
+
+// Decodes all x509 certificates found in the given content, either PEM encoded (one or multiple blocks) or DER encoded
+func DecodeX509Certificates(content []byte) ([]*x509.Certificate, error) {
+	certs := make([]*x509.Certificate, 0)
+	rest := content
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) > 0 {
+		return certs, nil
+	}
+	return x509.ParseCertificates(content)
+}
+
 // sorts list of strings alphabetically
 func SortStrListAl(list []interface{}) {
 	sort.SliceStable(list, func(i, j int) bool {
@@ -173,6 +219,234 @@ func DiffSuppressFunc4OptionalPrimitives(k, old, new string, d *schema.ResourceD
 	}
 }
 
+// resource data or resource diff
+type resourceDataGetter interface {
+	Get(string) interface{}
+	GetOk(string) (interface{}, bool)
+}
+
+// resource data getter backed by a map, i.e. to reuse resource data based functions with the content of nested blocks.
+// As for resource data, GetOk returns false for zero values.
+type mapResourceDataGetter map[string]interface{}
+
+func (m mapResourceDataGetter) Get(key string) interface{} {
+	return m[key]
+}
+
+func (m mapResourceDataGetter) GetOk(key string) (interface{}, bool) {
+	val, ok := m[key]
+	if !ok || val == nil {
+		return val, false
+	}
+	return val, !reflect.ValueOf(val).IsZero()
+}
+
+// Uses sha256 to calculate digest of the given content
+func CalcSha256Digest(content []byte) string {
+	hasher := sha256.New()
+	hasher.Write(content)
+	return hex.EncodeToString(hasher.Sum(nil))
+}
+
+// Reads the content of a file input given either as a path (attr), as inline content (attr_content) or base64 encoded (attr_base64).
+// returns false if none of them is set
+func ReadFileInput(d resourceDataGetter, attr string) ([]byte, bool, error) {
+	if path, ok := getFileInputPath(d, attr); ok {
+		b, err := os.ReadFile(path)
+		return b, true, err
+	}
+	if val, ok := d.GetOk(attr + "_content"); ok {
+		return []byte(val.(string)), true, nil
+	}
+	if val, ok := d.GetOk(attr + "_base64"); ok {
+		b, err := base64.StdEncoding.DecodeString(strings.TrimSpace(val.(string)))
+		if err != nil {
+			return nil, true, fmt.Errorf("%s_base64 is not a valid base64 encoded content. %s", attr, err.Error())
+		}
+		return b, true, nil
+	}
+	return nil, false, nil
+}
+
+// returns true if the file input is given either as a path (attr), as inline content (attr_content) or base64 encoded (attr_base64)
+func IsFileInputSet(d resourceDataGetter, attr string) bool {
+	if _, ok := getFileInputPath(d, attr); ok {
+		return true
+	}
+	for _, a := range []string{attr + "_content", attr + "_base64"} {
+		if _, ok := d.GetOk(a); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// the files opened to be uploaded by a request (see OpenFileInput), released by CloseFileInputs once the request is sent
+type FileInputs struct {
+	files []*os.File
+	temps []string
+}
+
+// Opens a file input (see ReadFileInput) to be uploaded. Inline content is written to a temporary file named after the given pattern (see os.CreateTemp).
+// The opened file is kept in inputs, the temporary files are removed by CloseFileInputs.
+// returns false if the file input is not set
+func OpenFileInput(d resourceDataGetter, attr string, pattern string, inputs *FileInputs) (*os.File, bool, error) {
+	if path, ok := getFileInputPath(d, attr); ok {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, true, err
+		}
+		inputs.files = append(inputs.files, file)
+		return file, true, nil
+	}
+	content, ok, err := ReadFileInput(d, attr)
+	if !ok || err != nil {
+		return nil, ok, err
+	}
+	file, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return nil, true, err
+	}
+	inputs.files = append(inputs.files, file)
+	inputs.temps = append(inputs.temps, file.Name())
+	if _, err := file.Write(content); err != nil {
+		return nil, true, err
+	}
+	if _, err := file.Seek(0, 0); err != nil {
+		return nil, true, err
+	}
+	return file, true, nil
+}
+
+// Closes the files opened by OpenFileInput and removes the temporary ones, they may hold secrets (i.e. private keys).
+// the files are closed first, an open file can't be removed on windows.
+// failures are logged along with the paths of the temporary files left behind.
+func CloseFileInputs(inputs *FileInputs) {
+	for _, file := range inputs.files {
+		// the generated clients close the files they upload
+		if err := file.Close(); err != nil && !errors.Is(err, os.ErrClosed) {
+			log.Printf("[WARN] unable to close file %s: %s", file.Name(), err)
+		}
+	}
+	for _, name := range inputs.temps {
+		if err := os.Remove(name); err != nil && !errors.Is(err, os.ErrNotExist) {
+			log.Printf("[WARN] unable to remove temporary file %s, it has to be removed manually: %s", name, err)
+		}
+	}
+	inputs.files = nil
+	inputs.temps = nil
+}
+
+// returns the path of a file input as configured.
+// The path kept in the state may be stale, its diff is suppressed when the file was moved without being changed (see DiffSuppressFunc4FilePath).
+// falls back to the value of the attribute when the configuration is not available (i.e. on import)
+func getFileInputPath(d resourceDataGetter, attr string) (string, bool) {
+	if rd, ok := d.(interface{ GetRawConfig() cty.Value }); ok {
+		raw := rd.GetRawConfig()
+		if !raw.IsNull() && raw.IsKnown() {
+			val := raw.GetAttr(attr)
+			if val.IsKnown() {
+				if val.IsNull() || val.AsString() == "" {
+					return "", false
+				}
+				return val.AsString(), true
+			}
+		}
+	}
+	if val, ok := d.GetOk(attr); ok {
+		return val.(string), true
+	}
+	return "", false
+}
+
+// suppresses the diff of a file path attribute when the file at the new path has the same content as the uploaded one (identified by the digest attribute).
+// The files are uploaded from the configured path, not from the one kept in the state (see getFileInputPath)
+func DiffSuppressFunc4FilePath(digestAttr string) schema.SchemaDiffSuppressFunc {
+	return func(k, old, new string, d *schema.ResourceData) bool {
+		if old == "" || new == "" {
+			return false
+		}
+		b, err := os.ReadFile(new)
+		if err != nil {
+			return false
+		}
+		return CalcSha256Digest(b) == d.Get(digestAttr).(string)
+	}
+}
+
+// computes the digest of the given file inputs and sets it to the digest attribute (attr_digest) when the content has changed
+func CustomizeDiff4FileInputDigests(d *schema.ResourceDiff, attrs ...string) error {
+	for _, attr := range attrs {
+		digestAttr := attr + "_digest"
+		if !d.NewValueKnown(attr) || !d.NewValueKnown(attr+"_content") || !d.NewValueKnown(attr+"_base64") {
+			if err := d.SetNewComputed(digestAttr); err != nil {
+				return err
+			}
+			continue
+		}
+		content, ok, err := ReadFileInput(d, attr)
+		if err != nil {
+			return fmt.Errorf("unable to read %s. %s", attr, err.Error())
+		}
+		digest := ""
+		if ok {
+			digest = CalcSha256Digest(content)
+		}
+		if digest != d.Get(digestAttr).(string) {
+			if err := d.SetNew(digestAttr, digest); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// fails if a certificate of the given file inputs to be uploaded expires within the number of days set in min_validity_days.
+// The digest attributes (attr_digest) should already be computed (see CustomizeDiff4FileInputDigests), unchanged contents are not checked.
+func CustomizeDiff4MinValidityDays(d *schema.ResourceDiff, attrs ...string) error {
+	days := d.Get("min_validity_days").(int)
+	if days <= 0 {
+		return nil
+	}
+	limit := time.Now().AddDate(0, 0, days)
+	for _, attr := range attrs {
+		digestAttr := attr + "_digest"
+		if !d.NewValueKnown(digestAttr) || (d.Id() != "" && !d.HasChange(digestAttr)) {
+			continue
+		}
+		content, ok, err := ReadFileInput(d, attr)
+		if !ok || err != nil {
+			continue
+		}
+		certs, err := DecodeX509Certificates(content)
+		if err != nil {
+			return fmt.Errorf("unable to decode the certificates of %s. %s", attr, err.Error())
+		}
+		for _, cert := range certs {
+			if cert.NotAfter.Before(limit) {
+				return fmt.Errorf(
+					"certificate \"%s\" of %s expires on %s, it should be valid for at least %d days (min_validity_days)",
+					cert.Subject.String(), attr, cert.NotAfter.Format(time.RFC3339), days,
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// initializes the digest attributes (attr_digest) of the given file inputs if not set yet (i.e. resources created by previous versions)
+func InitFileInputDigests(d *schema.ResourceData, attrs ...string) {
+	for _, attr := range attrs {
+		digestAttr := attr + "_digest"
+		if d.Get(digestAttr).(string) != "" {
+			continue
+		}
+		if content, ok, err := ReadFileInput(d, attr); ok && err == nil {
+			d.Set(digestAttr, CalcSha256Digest(content))
+		}
+	}
+}
+
 // Compares string lists
 // returns true if they are the same, false otherwise
 func equalStrList(old, new interface{}) bool {
@@ -0,0 +1,5 @@
+data "anypoint_apim_outbound_policies" "policies" {
+  org_id = var.root_org
+  env_id = var.env_id
+  apim_id = "19250669"
+}
@@ -0,0 +1,6 @@
+data "anypoint_apim_outbound_policy" "policy" {
+  org_id = var.root_org
+  env_id = var.env_id
+  apim_id = "19250669"
+  id = "4720771"
+}
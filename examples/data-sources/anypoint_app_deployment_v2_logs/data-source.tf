@@ -0,0 +1,20 @@
+data "anypoint_app_deployment_v2_logs" "latest" {
+  deployment_id = "de32fc9d-6b25-4d6f-bd5e-cac32272b2f7"
+  org_id = var.root_org
+  env_id = var.env_id
+  tail = 50
+}
+
+data "anypoint_app_deployment_v2_logs" "errors" {
+  deployment_id = "de32fc9d-6b25-4d6f-bd5e-cac32272b2f7"
+  org_id = var.root_org
+  env_id = var.env_id
+  start_time = "2024-01-02T15:00:00Z"
+  end_time = "2024-01-02T16:00:00Z"
+  priority = "ERROR"
+  search = "ConnectionException"
+}
+
+output "replicas" {
+  value = data.anypoint_app_deployment_v2_logs.latest.replicas
+}
@@ -0,0 +1,12 @@
+data "anypoint_certificate_expiry" "expiring" {
+  org_id = var.org_id
+  env_id = var.env_id
+  vpc_id = var.vpc_id
+  days = 45
+}
+
+output "expiring_certificates" {
+  value = [
+    for c in data.anypoint_certificate_expiry.expiring.certificates : "${c.source} ${c.name} expires on ${c.expiration_date}"
+  ]
+}
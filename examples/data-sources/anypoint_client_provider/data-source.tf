@@ -0,0 +1,4 @@
+data "anypoint_client_provider" "provider" {
+  org_id = "xxxx-xxx-xxx"   # the business group id
+  id     = "xxxx-xxx-xxxx"  # client provider id
+}
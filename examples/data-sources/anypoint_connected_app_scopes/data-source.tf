@@ -0,0 +1,6 @@
+data "anypoint_connected_app_scopes" "catalog" {
+}
+
+output "env_scopes" {
+  value = [for s in data.anypoint_connected_app_scopes.catalog.scopes : s.scope if s.requires_env]
+}
@@ -0,0 +1,5 @@
+data "anypoint_hybrid_server" "server" {
+  org_id = var.org_id
+  env_id = var.env_id
+  server_id = 1234567
+}
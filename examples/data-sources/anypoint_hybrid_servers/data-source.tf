@@ -0,0 +1,5 @@
+data "anypoint_hybrid_servers" "disconnected" {
+  org_id = var.org_id
+  env_id = var.env_id
+  status = "DISCONNECTED"
+}
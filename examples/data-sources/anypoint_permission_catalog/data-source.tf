@@ -0,0 +1,11 @@
+data "anypoint_permission_catalog" "catalog" {
+  org_id = var.root_org
+  names = [
+    "Access Controls Admin",
+    "Read Applications"
+  ]
+}
+
+output "env_scoped_roles" {
+  value = [for r in data.anypoint_permission_catalog.catalog.roles : r.name if r.requires_env]
+}
@@ -0,0 +1,4 @@
+data "anypoint_user_invitations" "pending" {
+  org_id = var.root_org
+  status = "pending"
+}
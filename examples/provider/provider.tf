@@ -9,6 +9,23 @@ provider "anypoint" {
 
   access_token  = var.access_token      # optionally use ANYPOINT_ACCESS_TOKEN env var
 
+  # connected app using the jwt bearer grant, with client_id
+  # private_key_file = var.private_key_file   # optionally use ANYPOINT_PRIVATE_KEY_FILE env var
+  # jwt_subject      = var.jwt_subject        # optionally use ANYPOINT_JWT_SUBJECT env var
+
+  # connected app using a refresh token from the authorization code grant, with client_id/secret
+  # refresh_token = var.refresh_token         # optionally use ANYPOINT_REFRESH_TOKEN env var
+
+  # when no credentials are set, they are read from a profile of ~/.anypoint/credentials
+  # profile = "default"                       # optionally use ANYPOINT_PROFILE env var
+
+  # the org and environment used by the resources that do not set org_id and env_id
+  # default_org_id   = var.root_org             # optionally use ANYPOINT_DEFAULT_ORG_ID env var
+  # default_env_name = "Sandbox"                # optionally use ANYPOINT_DEFAULT_ENV_NAME env var
+
+  # enables deletion_protection by default for the environments of these types and their secret groups
+  # protected_environment_types = ["production"]
+
   # You may need to change the anypoint control plane: use 'eu' or 'us'
   # by default the control plane is 'us'
   cplane= var.cplane                    # optionnaly use ANYPOINT_CPLANE env var
@@ -0,0 +1,40 @@
+#Outbound Basic Auth Credentials Injection Policy Example
+resource "anypoint_apim_outbound_policy" "outbound_01" {
+  org_id = var.root_org
+  env_id = var.env_id
+  apim_id = anypoint_apim_flexgateway.fg.id
+  upstream_ids = [
+    anypoint_apim_flexgateway.fg.upstreams[0].id
+  ]
+  disabled = false
+  asset_group_id="68ef9520-24e9-4cf2-b2f5-620025690913"
+  asset_id="basic-authentication-outbound"
+  asset_version = "1.0.0"
+  configuration_data = jsonencode({
+    username = "backend-user"
+    password = "mySupaDupaPasswordWithALotOfCharacters"
+  })
+}
+
+#Outbound Headers Injection Policy Example
+resource "anypoint_apim_outbound_policy" "outbound_02" {
+  org_id = var.root_org
+  env_id = var.env_id
+  apim_id = anypoint_apim_flexgateway.fg.id
+  upstream_ids = [
+    for u in anypoint_apim_flexgateway.fg.upstreams : u.id
+  ]
+  disabled = false
+  asset_group_id="68ef9520-24e9-4cf2-b2f5-620025690913"
+  asset_id="header-injection"
+  asset_version = "1.3.1"
+  configuration_data = jsonencode({
+    inboundHeaders = []
+    outboundHeaders = [
+      {
+        key = "x-backend-client"
+        value = "flex-gateway"
+      }
+    ]
+  })
+}
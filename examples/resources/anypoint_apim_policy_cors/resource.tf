@@ -0,0 +1,18 @@
+resource "anypoint_apim_policy_cors" "policy01" {
+  org_id = var.root_org
+  env_id = var.env_id
+  apim_id = anypoint_apim_mule4.api.id
+  disabled = false
+  asset_version = "1.3.1"
+  configuration_data {
+    public_resource = false
+    support_credentials = true
+    origin_groups {
+      origins = ["https://www.example.com", "https://app.example.com"]
+      access_control_max_age = 60
+      allowed_methods = ["GET", "POST", "OPTIONS"]
+      headers = ["content-type", "authorization"]
+      exposed_headers = ["x-correlation-id"]
+    }
+  }
+}
\ No newline at end of file
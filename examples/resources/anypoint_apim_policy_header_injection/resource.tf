@@ -0,0 +1,17 @@
+resource "anypoint_apim_policy_header_injection" "policy01" {
+  org_id = var.root_org
+  env_id = var.env_id
+  apim_id = anypoint_apim_mule4.api.id
+  disabled = false
+  asset_version = "1.3.1"
+  configuration_data {
+    inbound_headers {
+      key = "x-correlation-id"
+      value = "#[uuid()]"
+    }
+    outbound_headers {
+      key = "x-powered-by"
+      value = "anypoint"
+    }
+  }
+}
\ No newline at end of file
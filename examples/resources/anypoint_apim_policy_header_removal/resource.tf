@@ -0,0 +1,11 @@
+resource "anypoint_apim_policy_header_removal" "policy01" {
+  org_id = var.root_org
+  env_id = var.env_id
+  apim_id = anypoint_apim_mule4.api.id
+  disabled = false
+  asset_version = "1.1.0"
+  configuration_data {
+    inbound_headers = ["x-internal-token"]
+    outbound_headers = ["server", "x-powered-by"]
+  }
+}
\ No newline at end of file
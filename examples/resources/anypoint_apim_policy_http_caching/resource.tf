@@ -0,0 +1,20 @@
+resource "anypoint_apim_policy_http_caching" "policy01" {
+  org_id = var.root_org
+  env_id = var.env_id
+  apim_id = anypoint_apim_mule4.api.id
+  disabled = false
+  asset_version = "1.1.0"
+  configuration_data {
+    http_caching_key = "#[attributes.requestPath]"
+    max_cache_entries = 10000
+    ttl = 600
+    distributed = true
+    persist_cache = false
+    use_http_cache_headers = true
+    invalidation_header = "x-invalidate-cache"
+  }
+  pointcut_data {
+    method_regex = ["GET"]
+    uri_template_regex = "/api/v1/.*"
+  }
+}
\ No newline at end of file
@@ -0,0 +1,14 @@
+resource "anypoint_apim_policy_ip_blocklist" "policy01" {
+  org_id = var.root_org
+  env_id = var.env_id
+  apim_id = anypoint_apim_mule4.api.id
+  disabled = false
+  asset_version = "1.1.0"
+  configuration_data {
+    ip_expression = "#[attributes.headers['x-forwarded-for']]"
+    ips = [
+      "172.16.10.5",
+      "192.168.100.0/24"
+    ]
+  }
+}
\ No newline at end of file
@@ -0,0 +1,7 @@
+variable "root_org" {
+  default = "xx1f55d6-213d-4f60-845c-207286484cd1"
+}
+
+variable "env_id" {
+  default = "18f23771-c78a-4be2-af8f-1bae66f43942"
+}
\ No newline at end of file
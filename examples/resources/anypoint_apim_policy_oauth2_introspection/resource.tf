@@ -0,0 +1,16 @@
+resource "anypoint_apim_policy_oauth2_introspection" "policy01" {
+  org_id = var.root_org
+  env_id = var.env_id
+  apim_id = anypoint_apim_mule4.api.id
+  disabled = false
+  asset_version = "1.2.0"
+  configuration_data {
+    introspection_url = "https://idp.example.com/oauth2/introspect"
+    authorization_value = "Basic dXNlcjpwYXNzd29yZA=="
+    validated_token_ttl = 600
+    scopes = "read write"
+    expose_headers = false
+    skip_client_id_validation = false
+    consumer_by = "client_id"
+  }
+}
\ No newline at end of file
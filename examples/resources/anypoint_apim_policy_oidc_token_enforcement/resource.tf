@@ -0,0 +1,13 @@
+resource "anypoint_apim_policy_oidc_token_enforcement" "policy01" {
+  org_id = var.root_org
+  env_id = var.env_id
+  apim_id = anypoint_apim_mule4.api.id
+  disabled = false
+  asset_version = "1.5.0"
+  configuration_data {
+    scopes = "profile email"
+    expose_headers = false
+    skip_client_id_validation = false
+    rules = "#[vars.userProperties.active == true]"
+  }
+}
\ No newline at end of file
@@ -0,0 +1,19 @@
+resource "anypoint_apim_policy_spike_control" "policy01" {
+  org_id = var.root_org
+  env_id = var.env_id
+  apim_id = anypoint_apim_mule4.api.id
+  disabled = false
+  asset_version = "1.2.1"
+  configuration_data {
+    maximum_requests = 10
+    time_period_in_milliseconds = 1000
+    delay_time_in_millis = 1000
+    delay_attempts = 1
+    queuing_limit = 5
+    expose_headers = true
+  }
+  pointcut_data {
+    method_regex = ["GET", "POST"]
+    uri_template_regex = "/api/v1/.*"
+  }
+}
\ No newline at end of file
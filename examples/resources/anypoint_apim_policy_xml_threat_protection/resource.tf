@@ -0,0 +1,15 @@
+resource "anypoint_apim_policy_xml_threat_protection" "policy01" {
+  org_id = var.root_org
+  env_id = var.env_id
+  apim_id = anypoint_apim_mule4.api.id
+  disabled = false
+  asset_version = "1.2.0"
+  configuration_data {
+    max_node_depth = 10
+    max_attribute_count_per_element = 10
+    max_child_count = 100
+    max_text_length = 1024
+    max_attribute_length = 256
+    max_comment_length = -1
+  }
+}
\ No newline at end of file
@@ -0,0 +1,7 @@
+# In order for the import to work, you should provide a ID composed of the following:
+#  {ORG_ID}/{CLIENT_PROVIDER_ID}
+
+terraform import \
+  -var-file params.tfvars.json \          #variables file
+  anypoint_client_provider.example1 \     #resource name
+  aa1f55d6-213d-4f60-845c-201282484cd1/452a2081-5bde-4fb9-9a8b-54d180ee2358    #resource ID
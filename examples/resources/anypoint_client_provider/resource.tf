@@ -0,0 +1,36 @@
+resource "anypoint_client_provider" "example1" {
+  org_id = var.root_org
+  name = "openid connect client provider"
+  description = "client provider managed by terraform"
+
+  issuer            = "https://idp.example.com/auth/realms/master"
+  registration_url  = "https://idp.example.com/auth/realms/master/clients-registrations/openid-connect"
+  introspection_url = "https://idp.example.com/auth/realms/master/protocol/openid-connect/token/introspect"
+  authorize_url     = "https://idp.example.com/auth/realms/master/protocol/openid-connect/auth"
+  token_url         = "https://idp.example.com/auth/realms/master/protocol/openid-connect/token"
+
+  client_id     = "anypoint-introspection"
+  client_secret = "63b376f8-3ece-44f6-869c-33fe9022fdc4"
+  registration_auth = "Bearer my-initial-access-token"
+
+  connection_timeout = 5000
+  read_timeout       = 5000
+
+  allow_untrusted_certificates = false
+  allow_client_import          = true
+
+  environment_ids = [
+    "7074fcdd-9b23-4ab3-97c8-5db5f4adf17d"
+  ]
+}
+
+# the client provider can then be used by api instances
+resource "anypoint_apim_mule4" "api" {
+  org_id = var.root_org
+  env_id = "7074fcdd-9b23-4ab3-97c8-5db5f4adf17d"
+  asset_group_id = var.root_org
+  asset_id = "my-api"
+  asset_version = "1.0.0"
+  endpoint_uri = "https://my.api.example.com"
+  provider_id = anypoint_client_provider.example1.id
+}
@@ -50,4 +50,8 @@ resource "anypoint_cloudhub2_shared_space_deployment" "deployment" {
       }
     }
   }
-}
\ No newline at end of file
+
+  restart_trigger = {
+    restarted_at = "2024-01-01T00:00:00Z"
+  }
+}
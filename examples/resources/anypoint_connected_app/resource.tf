@@ -2,6 +2,9 @@ resource "anypoint_connected_app" "my_conn_app_its_own_behalf" {
     name = "its own behalf"
     grant_types = ["client_credentials"]
     audience = "internal"
+    # rotate the secret every 90 days or whenever the trigger changes
+    rotate_after_days = 90
+    rotation_trigger = "2024-01"
 
     scope {
         scope = "profile"
@@ -0,0 +1,31 @@
+resource "anypoint_hybrid_cluster" "unicast" {
+  org_id = var.root_org
+  env_id = var.env_id
+  name = "unicast-cluster-example"
+  multicast_enabled = false
+
+  servers {
+    server_id = 1234567
+    server_ip = "10.0.0.11"
+  }
+
+  servers {
+    server_id = 1234568
+    server_ip = "10.0.0.12"
+  }
+}
+
+resource "anypoint_hybrid_cluster" "multicast" {
+  org_id = var.root_org
+  env_id = var.env_id
+  name = "multicast-cluster-example"
+  multicast_enabled = true
+
+  servers {
+    server_id = 1234569
+  }
+
+  servers {
+    server_id = 1234570
+  }
+}
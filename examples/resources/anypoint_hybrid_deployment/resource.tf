@@ -0,0 +1,29 @@
+resource "anypoint_hybrid_deployment" "exchange" {
+  org_id = var.root_org
+  env_id = var.env_id
+  name = "hybrid-exchange-app"
+  target_id = 1234567
+
+  exchange {
+    group_id = var.root_org
+    artifact_id = "my-mule-app"
+    version = "1.0.0"
+  }
+
+  properties = {
+    "http.port" = "8081"
+    "env" = "dev"
+  }
+}
+
+resource "anypoint_hybrid_deployment" "local" {
+  org_id = var.root_org
+  env_id = var.env_id
+  name = "hybrid-local-app"
+  target_id = 1234568
+  artifact_path = "${path.module}/target/my-mule-app-1.0.0-mule-application.jar"
+
+  timeouts {
+    create = "15m"
+  }
+}
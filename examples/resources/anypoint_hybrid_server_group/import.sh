@@ -0,0 +1,7 @@
+# In order for the import to work, you should provide a ID composed of the following:
+#  {ORG_ID}/{ENV_ID}/{SERVER_GROUP_ID}
+
+terraform import \
+  -var-file params.tfvars.json \    #variables file
+  anypoint_hybrid_server_group.group \                #resource name
+  aa1f55d6-213d-4f60-845c-207286484cd1/18f23771-c78a-4be2-af8f-1bae66f43942/1234567   #resource ID
@@ -0,0 +1,6 @@
+resource "anypoint_hybrid_server_group" "group" {
+  org_id = var.root_org
+  env_id = var.env_id
+  name = "server-group-example"
+  server_ids = [ 1234567, 1234568 ]
+}
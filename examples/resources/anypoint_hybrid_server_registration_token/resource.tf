@@ -0,0 +1,7 @@
+resource "anypoint_hybrid_server_registration_token" "token" {
+  org_id = var.root_org
+  env_id = var.env_id
+  triggers = {
+    rotation = "2024-01"
+  }
+}
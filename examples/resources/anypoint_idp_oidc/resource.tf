@@ -30,4 +30,16 @@ resource "anypoint_idp_oidc" "example2" {
 
     allow_untrusted_certificates = true
   }
-}
\ No newline at end of file
+}
+
+resource "anypoint_idp_oidc" "example3" {
+  org_id = var.root_org
+  name = "openid connect provider from discovery"
+  discovery_url = "https://idp.example.com/auth/realms/master"
+  oidc_provider {
+    client_credentials_id     = "anypoint-oidc"
+    client_credentials_secret = "63b376f8-3ece-44f6-869c-33fe9022fdc4"
+
+    allow_untrusted_certificates = false
+  }
+}
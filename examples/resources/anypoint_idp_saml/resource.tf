@@ -36,4 +36,31 @@ resource "anypoint_idp_saml" "example2" {
   }
   sp_sign_on_url  = "http://idp.example.com/auth/realms/master/protocol/saml"
   sp_sign_out_url = "http://idp.example.com/auth/realms/master/protocol/saml"
-}
\ No newline at end of file
+}
+
+resource "anypoint_idp_saml" "example3" {
+  org_id = var.root_org
+  name = "SAML 2.0 provider from metadata"
+  metadata_url = "https://idp.example.com/app/exk123/sso/saml/metadata"
+  saml {
+    audience = "example3.anypoint.mulesoft.com"
+
+    sp_initiated_sso_enabled          = true
+    idp_initiated_sso_enabled         = true
+    require_encrypted_saml_assertions = false
+  }
+}
+
+resource "anypoint_idp_saml" "example4" {
+  org_id = var.root_org
+  name = "SAML 2.0 provider from metadata file"
+  metadata_xml = file("${path.module}/idp-metadata.xml")
+  saml {
+    audience = "example4.anypoint.mulesoft.com"
+  }
+  sp_sign_out_url = "http://idp.example.com/logout"
+}
+
+output "saml_signing_certificate_expiry" {
+  value = anypoint_idp_saml.example3.signing_certificate_expiry
+}
@@ -0,0 +1,6 @@
+# In order for the import to work, you should provide the id of the organization
+
+terraform import \
+  -var-file params.tfvars.json \          #variables file
+  anypoint_org_security_settings.security \            #resource name
+  aa1f55d6-213d-4f60-845c-201282484cd1    #resource ID
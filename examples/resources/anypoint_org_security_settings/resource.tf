@@ -0,0 +1,8 @@
+resource "anypoint_org_security_settings" "security" {
+  org_id = var.root_org
+  mfa_required = "enabled"
+  mfa_exempt_user_ids = [
+    var.ci_user_id
+  ]
+  session_timeout = 60
+}
@@ -58,4 +58,10 @@ resource "anypoint_rtf_deployment" "deployment" {
       }
     }
   }
-}
\ No newline at end of file
+
+  rollout {
+    strategy      = "blue_green"
+    promote       = false # set to true to move the public url to the candidate deployment
+    auto_rollback = true
+  }
+}
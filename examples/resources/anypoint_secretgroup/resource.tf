@@ -3,4 +3,5 @@ resource "anypoint_secretgroup" "sg" {
   env_id = var.env_id
   name = "sg-example"
   downloadable = true
-}
\ No newline at end of file
+  force_unlock = false
+}
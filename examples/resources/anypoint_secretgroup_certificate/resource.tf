@@ -6,4 +6,14 @@ resource "anypoint_secretgroup_certificate" "cert" {
   name = "terraform-cert-example"
   type = "PEM"
   certificate = "${path.module}/keys/myserver.local.crt"
-}
\ No newline at end of file
+}
+
+resource "anypoint_secretgroup_certificate" "inline" {
+  org_id = var.root_org
+  env_id = var.env_id
+  sg_id = anypoint_secretgroup.sg.id
+  name = "terraform-cert-example-inline"
+  type = "PEM"
+  certificate_content = data.vault_generic_secret.tls.data["certificate"]
+  min_validity_days = 30
+}
@@ -0,0 +1,31 @@
+resource "anypoint_secretgroup_copy" "copy" {
+  org_id = var.root_org
+  source_env_id = var.source_env_id
+  source_sg_id = var.sg_id
+  target_env_id = var.target_env_id
+  name = "sg-example-copy"
+  finalize = true
+
+  keystore {
+    name = "terraform-keystore-pem"
+    certificate_content = file("${path.module}/cert/cert.pem")
+    key_content = file("${path.module}/cert/key.pem")
+  }
+
+  keystore {
+    name = "terraform-keystore-jks"
+    keystore_base64 = filebase64("${path.module}/cert/keystore.jks")
+    store_passphrase = var.store_passphrase
+    key_passphrase = var.key_passphrase
+  }
+
+  truststore {
+    name = "terraform-truststore-pem"
+    truststore_content = file("${path.module}/cert/truststore.pem")
+  }
+
+  certificate {
+    name = "terraform-certificate"
+    certificate_content = file("${path.module}/cert/cert.pem")
+  }
+}
@@ -0,0 +1,23 @@
+variable "root_org" {
+  default = "xx1f55d6-213d-4f60-845c-207286484cd1"
+}
+
+variable "source_env_id" {
+  default = "18f23771-c78a-4be2-af8f-1bae66f43942"
+}
+
+variable "target_env_id" {
+  default = "7074fcdd-9b23-4ab3-97c8-5db5f4adf17d"
+}
+
+variable "sg_id" {
+  default = "18f23771-c78a-4be2-af8f-1bae66f43942"
+}
+
+variable "store_passphrase" {
+  sensitive = true
+}
+
+variable "key_passphrase" {
+  sensitive = true
+}
@@ -0,0 +1,22 @@
+resource "anypoint_secretgroup_keystore" "keystore" {
+  org_id = var.root_org
+  env_id = var.env_id
+  sg_id = var.sg_id
+  name = "terraform-keystore-pem"
+  type = "PEM"
+  certificate = "${path.module}/cert/cert.pem"
+  key = "${path.module}/cert/key.pem"
+}
+
+resource "anypoint_secretgroup_finalize" "finalize" {
+  org_id = var.root_org
+  env_id = var.env_id
+  sg_id = var.sg_id
+  triggers = {
+    keystore = anypoint_secretgroup_keystore.keystore.certificate_digest
+  }
+
+  timeouts {
+    create = "15m"
+  }
+}
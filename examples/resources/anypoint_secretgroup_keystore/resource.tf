@@ -6,6 +6,7 @@ resource "anypoint_secretgroup_keystore" "keystore" {
   type = "PEM"
   key = "${path.module}/keys/myserver.local.key"
   certificate = "${path.module}/keys/myserver.local.crt"
+  min_validity_days = 30
 }
 
 resource "anypoint_secretgroup_keystore" "jks" {
@@ -19,4 +20,27 @@ resource "anypoint_secretgroup_keystore" "jks" {
   alias = "terraform_example"
   store_passphrase = "123456"
   key_passphrase = "123456"
-}
\ No newline at end of file
+}
+
+# keystore from inline content (i.e. read from vault)
+resource "anypoint_secretgroup_keystore" "inline" {
+  org_id = var.root_org
+  env_id = var.env_id
+  sg_id = anypoint_secretgroup.sg.id
+  name = "terraform-keystore-example-inline-01"
+  type = "PEM"
+  key_content = data.vault_generic_secret.tls.data["key"]
+  certificate_content = data.vault_generic_secret.tls.data["certificate"]
+}
+
+resource "anypoint_secretgroup_keystore" "p12" {
+  org_id = var.root_org
+  env_id = var.env_id
+  sg_id = anypoint_secretgroup.sg.id
+  name = "terraform-keystore-example-p12-01"
+  type = "PKCS12"
+  keystore_base64 = filebase64("${path.module}/keys/my-terraform.p12")
+  alias = "terraform_example"
+  store_passphrase = "123456"
+  key_passphrase = "123456"
+}
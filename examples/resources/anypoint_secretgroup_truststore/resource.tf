@@ -16,4 +16,23 @@ resource "anypoint_secretgroup_truststore" "jks" {
   truststore = "${path.module}/keys/my-terraform.keystore"
   algorithm = "PKIX"
   store_passphrase = "123456"
-}
\ No newline at end of file
+}
+resource "anypoint_secretgroup_truststore" "inline" {
+  org_id = var.root_org
+  env_id = var.env_id
+  sg_id = anypoint_secretgroup.sg.id
+  name = "terraform-truststore-example-inline-01"
+  type = "PEM"
+  truststore_content = data.vault_generic_secret.tls.data["ca"]
+}
+
+resource "anypoint_secretgroup_truststore" "jks_base64" {
+  org_id = var.root_org
+  env_id = var.env_id
+  sg_id = anypoint_secretgroup.sg.id
+  name = "terraform-truststore-example-jks-02"
+  type = "JKS"
+  truststore_base64 = filebase64("${path.module}/keys/my-terraform.keystore")
+  algorithm = "PKIX"
+  store_passphrase = "123456"
+}
@@ -0,0 +1,15 @@
+resource "anypoint_team_members_exclusive" "members" {
+  org_id = var.root_org
+  team_id = anypoint_team.team.id
+  membership_type = "member"
+  ignore_external_group_members = true
+
+  user_ids = [
+    anypoint_user.user.id
+  ]
+
+  usernames = [
+    "jane.doe",
+    "john.doe"
+  ]
+}
@@ -0,0 +1,7 @@
+# In order for the import to work, you should provide a ID composed of the following:
+#  {ORG_ID}/{INVITE_ID}
+
+terraform import \
+  -var-file params.tfvars.json \          #variables file
+  anypoint_user_invitation.invitation \            #resource name
+  aa1f55d6-213d-4f60-845c-201282484cd1/5c1e9a3b-7f7a-4d8e-9e54-3a0f2b1c6d7e    #resource ID
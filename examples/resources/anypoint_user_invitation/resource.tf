@@ -0,0 +1,10 @@
+resource "anypoint_user_invitation" "invitation" {
+  org_id = var.root_org
+  email = "jane.doe@example.com"
+  resend_when_expired = true
+
+  team {
+    team_id = anypoint_team.team.id
+    membership_type = "member"
+  }
+}